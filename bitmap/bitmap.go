@@ -0,0 +1,133 @@
+// Package bitmap implements the bit operations of Redis over byte slices.
+// Bits are numbered as in Redis: bit 0 is the most significant bit of the
+// first byte. Bytes past the end of a bitmap read as zero.
+package bitmap
+
+import "math/bits"
+
+// GetBit returns the bit at offset.
+func GetBit(b []byte, offset uint64) int {
+	i := offset / 8
+	if i >= uint64(len(b)) {
+		return 0
+	}
+	return int(b[i]>>(7-offset%8)) & 1
+}
+
+// SetBit sets the bit at offset to bit, growing b with zero bytes if needed,
+// and returns the bitmap along with the previous bit.
+func SetBit(b []byte, offset uint64, bit int) ([]byte, int) {
+	b = Grow(b, offset+1)
+	old := GetBit(b, offset)
+
+	mask := byte(1) << (7 - offset%8)
+	if bit == 0 {
+		b[offset/8] &^= mask
+	} else {
+		b[offset/8] |= mask
+	}
+	return b, old
+}
+
+// Grow pads b with zero bytes until it holds at least n bits.
+func Grow(b []byte, n uint64) []byte {
+	size := (n + 7) / 8
+	if size <= uint64(len(b)) {
+		return b
+	}
+	return append(b, make([]byte, size-uint64(len(b)))...)
+}
+
+// Count returns the number of bits set from bit start to bit end, both
+// included.
+func Count(b []byte, start, end uint64) int {
+	end = min(end, uint64(len(b))*8-1)
+	if len(b) == 0 || start > end {
+		return 0
+	}
+
+	first, last := start/8, end/8
+	count := 0
+	for _, c := range b[first : last+1] {
+		count += bits.OnesCount8(c)
+	}
+	// Discount the bits of the first and last bytes out of the range.
+	count -= bits.OnesCount8(b[first] >> (8 - start%8) << (8 - start%8))
+	count -= bits.OnesCount8(b[last] << (end%8 + 1))
+	return count
+}
+
+// Pos returns the offset of the first bit equal to bit from bit start to bit
+// end, both included, or -1 if there is none.
+func Pos(b []byte, bit int, start, end uint64) int64 {
+	end = min(end, uint64(len(b))*8-1)
+	if len(b) == 0 || start > end {
+		return -1
+	}
+
+	// Bytes with no bit to look for are skipped whole.
+	skip := byte(0)
+	if bit == 0 {
+		skip = 0xff
+	}
+	for offset := start; offset <= end; {
+		if offset%8 == 0 && offset+7 <= end && b[offset/8] == skip {
+			offset += 8
+			continue
+		}
+		if GetBit(b, offset) == bit {
+			return int64(offset)
+		}
+		offset++
+	}
+	return -1
+}
+
+// And returns the bitwise AND of srcs, as long as the longest of them.
+func And(srcs ...[]byte) []byte {
+	return apply(srcs, func(x, y byte) byte { return x & y })
+}
+
+// Or returns the bitwise OR of srcs, as long as the longest of them.
+func Or(srcs ...[]byte) []byte {
+	return apply(srcs, func(x, y byte) byte { return x | y })
+}
+
+// Xor returns the bitwise XOR of srcs, as long as the longest of them.
+func Xor(srcs ...[]byte) []byte {
+	return apply(srcs, func(x, y byte) byte { return x ^ y })
+}
+
+// Not returns the bitwise NOT of src.
+func Not(src []byte) []byte {
+	dst := make([]byte, len(src))
+	for i, c := range src {
+		dst[i] = ^c
+	}
+	return dst
+}
+
+// apply folds srcs with op byte by byte, the shorter ones padded with zero
+// bytes.
+func apply(srcs [][]byte, op func(x, y byte) byte) []byte {
+	size := 0
+	for _, src := range srcs {
+		size = max(size, len(src))
+	}
+
+	dst := make([]byte, size)
+	for j, src := range srcs {
+		for i := range dst {
+			var c byte
+			if i < len(src) {
+				c = src[i]
+			}
+			if j == 0 {
+				dst[i] = c
+			} else {
+				dst[i] = op(dst[i], c)
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,136 @@
+package bitmap
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBits(t *testing.T) {
+	var b []byte
+	b, old := SetBit(b, 7, 1)
+	assert.Equalf(t, old, 0, "SetBit(7, 1) = %d; want 0", old)
+	assert.Equal(t, b, []byte{0x01})
+	b, _ = SetBit(b, 9, 1)
+	assert.Equal(t, b, []byte{0x01, 0x40})
+	b, old = SetBit(b, 9, 0)
+	assert.Equalf(t, old, 1, "SetBit(9, 0) = %d; want 1", old)
+
+	assert.Equalf(t, GetBit(b, 7), 1, "GetBit(7) = %d; want 1", GetBit(b, 7))
+	assert.Equalf(t, GetBit(b, 100), 0, "GetBit(100) = %d; want 0", GetBit(b, 100))
+}
+
+func TestCount(t *testing.T) {
+	b := []byte("foobar")
+
+	tests := []struct {
+		start, end uint64
+		want       int
+	}{
+		{0, 47, 26},
+		{0, 7, 4},
+		{8, 15, 6},
+		{5, 30, 17},
+		{1, 1, 1},
+		{2, 2, 1},
+		{40, 1000, 4},
+		{48, 100, 0},
+		{9, 8, 0},
+	}
+	for _, tt := range tests {
+		got := Count(b, tt.start, tt.end)
+		assert.Equalf(t, got, tt.want, "Count(%d, %d) = %d; want %d", tt.start, tt.end, got, tt.want)
+	}
+}
+
+func TestPos(t *testing.T) {
+	b := []byte{0xff, 0xf0, 0x00}
+
+	tests := []struct {
+		bit        int
+		start, end uint64
+		want       int64
+	}{
+		{0, 0, 23, 12},
+		{1, 0, 23, 0},
+		{1, 12, 23, -1},
+		{0, 0, 11, -1},
+		{1, 3, 5, 3},
+		{0, 16, 100, 16},
+	}
+	for _, tt := range tests {
+		got := Pos(b, tt.bit, tt.start, tt.end)
+		assert.Equalf(t, got, tt.want, "Pos(%d, %d, %d) = %d; want %d", tt.bit, tt.start, tt.end, got, tt.want)
+	}
+}
+
+func TestOps(t *testing.T) {
+	a, b := []byte{0xf0, 0x0f}, []byte{0xff}
+
+	assert.Equal(t, And(a, b), []byte{0xf0, 0x00})
+	assert.Equal(t, Or(a, b), []byte{0xff, 0x0f})
+	assert.Equal(t, Xor(a, b), []byte{0x0f, 0x0f})
+	assert.Equal(t, Not(a), []byte{0x0f, 0xf0})
+}
+
+func TestField(t *testing.T) {
+	var b []byte
+	i8 := Field{Signed: true, Bits: 8}
+	u4 := Field{Bits: 4}
+
+	b = i8.Set(b, 0, -3)
+	assert.Equal(t, b, []byte{0xfd})
+	got := i8.Get(b, 0)
+	assert.Equalf(t, got, int64(-3), "i8.Get(0) = %d; want -3", got)
+	got = u4.Get(b, 0)
+	assert.Equalf(t, got, int64(15), "u4.Get(0) = %d; want 15", got)
+
+	// Fields need not be aligned on bytes.
+	b = u4.Set(b, 6, 9)
+	assert.Equal(t, b, []byte{0xfe, 0x40})
+	got = u4.Get(b, 6)
+	assert.Equalf(t, got, int64(9), "u4.Get(6) = %d; want 9", got)
+
+	i64 := Field{Signed: true, Bits: 64}
+	b = i64.Set(b, 3, math.MinInt64)
+	got = i64.Get(b, 3)
+	assert.Equalf(t, got, int64(math.MinInt64), "i64.Get(3) = %d; want %d", got, int64(math.MinInt64))
+}
+
+func TestFieldAdd(t *testing.T) {
+	i8 := Field{Signed: true, Bits: 8}
+	u8 := Field{Bits: 8}
+	i64 := Field{Signed: true, Bits: 64}
+
+	tests := []struct {
+		field        Field
+		value, delta int64
+		overflow     Overflow
+		want         int64
+		wantOK       bool
+	}{
+		{i8, 100, 20, Wrap, 120, true},
+		{i8, 100, 100, Wrap, -56, true},
+		{i8, 100, 100, Sat, 127, true},
+		{i8, 100, 100, Fail, 0, false},
+		{i8, -100, -100, Wrap, 56, true},
+		{i8, -100, -100, Sat, -128, true},
+		{i8, 1000, 0, Sat, 127, true},
+		{u8, 250, 10, Wrap, 4, true},
+		{u8, 250, 10, Sat, 255, true},
+		{u8, 5, -10, Wrap, 251, true},
+		{u8, 5, -10, Sat, 0, true},
+		{u8, 5, -10, Fail, 0, false},
+		{u8, -1, 0, Sat, 255, true},
+		{i64, math.MaxInt64, 1, Wrap, math.MinInt64, true},
+		{i64, math.MaxInt64, 1, Sat, math.MaxInt64, true},
+		{i64, math.MinInt64, -1, Sat, math.MinInt64, true},
+		{i64, -5, math.MaxInt64, Fail, math.MaxInt64 - 5, true},
+	}
+	for _, tt := range tests {
+		got, ok := tt.field.Add(tt.value, tt.delta, tt.overflow)
+		assert.Equalf(t, got, tt.want, "%v.Add(%d, %d, %d) = %d; want %d", tt.field, tt.value, tt.delta, tt.overflow, got, tt.want)
+		assert.Equalf(t, ok, tt.wantOK, "%v.Add(%d, %d, %d) ok = %t; want %t", tt.field, tt.value, tt.delta, tt.overflow, ok, tt.wantOK)
+	}
+}
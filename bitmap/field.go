@@ -0,0 +1,124 @@
+package bitmap
+
+import "math"
+
+// Overflow tells how an integer field handles a value that does not fit.
+type Overflow int
+
+const (
+	// Wrap keeps the low bits of the value, as integer arithmetic does.
+	Wrap Overflow = iota
+	// Sat saturates the value to the field's minimum or maximum.
+	Sat
+	// Fail leaves the field unchanged.
+	Fail
+)
+
+// Field is an integer of Bits bits stored at any bit offset of a bitmap, as
+// used by BITFIELD. A signed field holds up to 64 bits, an unsigned one up to
+// 63 so that its values fit an int64.
+type Field struct {
+	Signed bool
+	Bits   int
+}
+
+// Valid reports whether f has a width BITFIELD supports.
+func (f Field) Valid() bool {
+	if f.Signed {
+		return f.Bits >= 1 && f.Bits <= 64
+	}
+	return f.Bits >= 1 && f.Bits <= 63
+}
+
+// Get returns the value of the field at offset.
+func (f Field) Get(b []byte, offset uint64) int64 {
+	var value uint64
+	for i := uint64(0); i < uint64(f.Bits); i++ {
+		value = value<<1 | uint64(GetBit(b, offset+i))
+	}
+	if f.Signed && f.Bits < 64 && value>>(f.Bits-1) == 1 {
+		// Extend the sign.
+		value |= math.MaxUint64 << f.Bits
+	}
+	return int64(value)
+}
+
+// Set stores the low bits of value in the field at offset, growing b if
+// needed, and returns the bitmap.
+func (f Field) Set(b []byte, offset uint64, value int64) []byte {
+	b = Grow(b, offset+uint64(f.Bits))
+	for i := uint64(0); i < uint64(f.Bits); i++ {
+		bit := int(uint64(value)>>(uint64(f.Bits)-1-i)) & 1
+		b, _ = SetBit(b, offset+i, bit)
+	}
+	return b
+}
+
+// Add returns value plus delta, brought into the range of the field as
+// overflow tells, and false if it does not fit and overflow is Fail. The
+// value of an unsigned field is read as a uint64.
+func (f Field) Add(value, delta int64, overflow Overflow) (int64, bool) {
+	if f.Signed {
+		return f.addSigned(value, delta, overflow)
+	}
+	return f.addUnsigned(uint64(value), delta, overflow)
+}
+
+func (f Field) addSigned(value, delta int64, overflow Overflow) (int64, bool) {
+	maxValue := int64(math.MaxInt64)
+	if f.Bits < 64 {
+		maxValue = 1<<(f.Bits-1) - 1
+	}
+	minValue := -maxValue - 1
+
+	var limit int64
+	switch {
+	case value > maxValue || (delta > 0 && value >= 0 && delta > maxValue-value) ||
+		(delta > 0 && value < 0 && f.Bits < 64 && delta > maxValue-value):
+		limit = maxValue
+	case value < minValue || (delta < 0 && value < 0 && delta < minValue-value) ||
+		(delta < 0 && value >= 0 && f.Bits < 64 && delta < minValue-value):
+		limit = minValue
+	default:
+		return value + delta, true
+	}
+
+	switch overflow {
+	case Sat:
+		return limit, true
+	case Fail:
+		return 0, false
+	}
+	// Keep the low bits and extend their sign.
+	sum := uint64(value) + uint64(delta)
+	if f.Bits < 64 {
+		if sum>>(f.Bits-1)&1 == 1 {
+			sum |= math.MaxUint64 << f.Bits
+		} else {
+			sum &^= math.MaxUint64 << f.Bits
+		}
+	}
+	return int64(sum), true
+}
+
+func (f Field) addUnsigned(value uint64, delta int64, overflow Overflow) (int64, bool) {
+	maxValue := uint64(1)<<f.Bits - 1
+
+	var limit uint64
+	switch {
+	case value > maxValue || (delta > 0 && uint64(delta) > maxValue-value):
+		limit = maxValue
+	case delta < 0 && uint64(-delta) > value:
+		limit = 0
+	default:
+		return int64(value + uint64(delta)), true
+	}
+
+	switch overflow {
+	case Sat:
+		return int64(limit), true
+	case Fail:
+		return 0, false
+	}
+	return int64((value + uint64(delta)) & maxValue), true
+}
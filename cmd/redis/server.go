@@ -7,10 +7,11 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 
-	"github.com/trinhdaiphuc/go-memcache/gomap"
-	"github.com/trinhdaiphuc/go-memcache/hashmap"
 	"github.com/trinhdaiphuc/go-memcache/internal/handler"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
 	"github.com/trinhdaiphuc/go-memcache/resp"
 )
 
@@ -21,21 +22,22 @@ func main() {
 }
 
 type Server struct {
-	listener   net.Listener
-	handler    handler.Map
-	mapString  gomap.Map[string, string]
-	hashString hashmap.HashMap[string, string]
-	quit       chan os.Signal
+	listener net.Listener
+	handler  handler.Map
+	keyspace keyspace.Keyspace
+	ctx      handler.Context
+	quit     chan os.Signal
 }
 
 func NewServer() *Server {
 	s := &Server{
-		quit:       make(chan os.Signal, 1),
-		handler:    handler.NewMap(),
-		mapString:  gomap.NewMap[string, string](),
-		hashString: hashmap.NewHashMap[string, string](),
+		quit:     make(chan os.Signal, 1),
+		handler:  handler.NewMap(),
+		keyspace: keyspace.NewKeyspace(),
 	}
 
+	s.ctx = handler.NewContext(s.keyspace)
+
 	signal.Notify(s.quit, os.Interrupt)
 	return s
 }
@@ -79,18 +81,15 @@ func (s *Server) run() {
 func (s *Server) processConnection(conn net.Conn) {
 	defer conn.Close()
 
-	for {
-		reader := bufio.NewReader(conn)
+	done := make(chan struct{})
+	commands := newCommandQueue()
+	go s.readCommands(conn, commands, done)
 
-		respCmd := resp.NewRESPCommand()
-		err := respCmd.Read(reader)
-		if err != nil {
-			if err == io.EOF {
-				fmt.Println("Connection closed")
-				break
-			}
-			fmt.Println("Error reading from connection: ", err.Error())
-			break
+	ctx := s.ctx.WithDone(done)
+	for {
+		respCmd, ok := commands.pop()
+		if !ok {
+			return
 		}
 
 		// Write back to the client
@@ -100,16 +99,138 @@ func (s *Server) processConnection(conn net.Conn) {
 				conn.Write([]byte(resp.NewErrorExpression("No command provided").Serialize()))
 				continue
 			}
-			cmd := args[0].Value().(string)
+			cmd := strings.ToUpper(args[0].Value().(string))
 			h, ok := s.handler[cmd]
 			if !ok {
 				conn.Write([]byte(resp.NewErrorExpression("Unknown command").Serialize()))
 				continue
 			}
-			ctx := handler.NewContext(s.mapString, s.hashString)
+
+			commands.setBlocking(handler.IsBlocking(cmd))
 			result := h.Handle(ctx, args[1:])
+			commands.setBlocking(false)
 			conn.Write([]byte(result.Serialize()))
 			continue
 		}
 	}
 }
+
+// readCommands reads the commands sent on conn until the connection fails,
+// then closes done. It keeps reading while a blocking command is handled, so
+// that a client blocked in BLPOP is unblocked as soon as it disconnects, even
+// with more commands pipelined behind.
+func (s *Server) readCommands(conn net.Conn, commands *commandQueue, done chan<- struct{}) {
+	defer close(done)
+
+	reader := bufio.NewReader(conn)
+	for {
+		respCmd := resp.NewRESPCommand()
+		err := respCmd.Read(reader)
+		if err != nil {
+			commands.close()
+			if err == io.EOF {
+				fmt.Println("Connection closed")
+				return
+			}
+			fmt.Println("Error reading from connection: ", err.Error())
+			return
+		}
+
+		if !commands.push(respCmd) {
+			commands.abort()
+			fmt.Println("Closing connection: too many commands queued while blocked")
+			return
+		}
+	}
+}
+
+// maxQueuedCommands is the number of commands read from a connection ahead of
+// the one being handled. Reading pauses once it is reached, unless the command
+// being handled is blocking: the client is then disconnected, as Redis
+// disconnects a client exceeding its query buffer limit.
+const maxQueuedCommands = 1 << 16
+
+// commandQueue holds the commands read from a connection ahead of the one
+// being handled.
+type commandQueue struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	commands []*resp.Command
+	blocking bool
+	closed   bool
+}
+
+func newCommandQueue() *commandQueue {
+	q := &commandQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push queues cmd, waiting for room unless the command being handled is
+// blocking. It reports false if the queue is full and the client must be
+// disconnected instead.
+func (q *commandQueue) push(cmd *resp.Command) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.commands) >= maxQueuedCommands {
+		if q.blocking {
+			return false
+		}
+		q.cond.Wait()
+	}
+
+	q.commands = append(q.commands, cmd)
+	q.cond.Broadcast()
+	return true
+}
+
+// pop returns the next command, waiting for one to be read. It reports false
+// once the connection is closed and every command read from it was returned.
+func (q *commandQueue) pop() (*resp.Command, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.commands) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.commands) == 0 {
+		return nil, false
+	}
+
+	cmd := q.commands[0]
+	q.commands[0] = nil
+	q.commands = q.commands[1:]
+	q.cond.Broadcast()
+	return cmd, true
+}
+
+// setBlocking records whether the command being handled may block.
+func (q *commandQueue) setBlocking(blocking bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.blocking = blocking
+	q.cond.Broadcast()
+}
+
+// close marks the end of the connection. The commands already read are still
+// returned by pop.
+func (q *commandQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// abort marks the end of the connection and drops the commands not handled
+// yet.
+func (q *commandQueue) abort() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.commands = nil
+	q.cond.Broadcast()
+}
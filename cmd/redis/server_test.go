@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// connect serves a new in-memory connection with s and returns the client
+// side, together with a channel closed once s is done with the connection.
+func connect(s *Server) (net.Conn, <-chan struct{}) {
+	client, server := net.Pipe()
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		s.processConnection(server)
+	}()
+	return client, served
+}
+
+func TestBlockedClientDisconnectsWithPipelinedCommands(t *testing.T) {
+	s := NewServer()
+
+	blocked, served := connect(s)
+	_, err := blocked.Write([]byte("*3\r\n$5\r\nBLPOP\r\n$1\r\nk\r\n$1\r\n0\r\n*1\r\n$4\r\nPING\r\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, blocked.Close())
+
+	select {
+	case <-served:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the connection of the disconnected client is still served")
+	}
+
+	client, _ := connect(s)
+	defer client.Close()
+	reader := bufio.NewReader(client)
+
+	_, err = client.Write([]byte("*3\r\n$5\r\nLPUSH\r\n$1\r\nk\r\n$1\r\nv\r\n"))
+	assert.NoError(t, err)
+	reply, _ := reader.ReadString('\n')
+	assert.Equalf(t, ":1\r\n", reply, "LPUSH k v = %q; want :1", reply)
+
+	_, err = client.Write([]byte("*2\r\n$4\r\nLLEN\r\n$1\r\nk\r\n"))
+	assert.NoError(t, err)
+	reply, _ = reader.ReadString('\n')
+	assert.Equalf(t, ":1\r\n", reply, "LLEN k = %q; want :1", reply)
+}
+
+func TestCommandQueueLimitWhileBlocked(t *testing.T) {
+	q := newCommandQueue()
+	for i := 0; i < maxQueuedCommands; i++ {
+		assert.True(t, q.push(resp.NewRESPCommand()))
+	}
+
+	q.setBlocking(true)
+	assert.Falsef(t, q.push(resp.NewRESPCommand()), "q.push() = true; want false once full while blocked")
+
+	q.abort()
+	_, ok := q.pop()
+	assert.Falsef(t, ok, "q.pop() = true; want false once aborted")
+}
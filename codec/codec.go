@@ -0,0 +1,69 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec converts values of type T to and from their byte representation so
+// they can be kept in byte-oriented storage such as a gomap.Map[K, string].
+type Codec[T any] interface {
+	Encode(value T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+type jsonCodec[T any] struct {
+}
+
+func NewJSONCodec[T any]() Codec[T] {
+	return &jsonCodec[T]{}
+}
+
+func (c *jsonCodec[T]) Encode(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (c *jsonCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+type gobCodec[T any] struct {
+}
+
+func NewGobCodec[T any]() Codec[T] {
+	return &gobCodec[T]{}
+}
+
+func (c *gobCodec[T]) Encode(value T) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *gobCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+	return value, err
+}
+
+type bytesCodec struct {
+}
+
+// NewBytesCodec returns a Codec that stores byte slices as they are.
+func NewBytesCodec() Codec[[]byte] {
+	return &bytesCodec{}
+}
+
+func (c *bytesCodec) Encode(value []byte) ([]byte, error) {
+	return value, nil
+}
+
+func (c *bytesCodec) Decode(data []byte) ([]byte, error) {
+	return data, nil
+}
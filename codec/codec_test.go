@@ -0,0 +1,64 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trinhdaiphuc/go-memcache/gomap"
+)
+
+type User struct {
+	ID       int64
+	Username string
+	Email    string
+}
+
+func TestCodecs(t *testing.T) {
+	user := User{ID: 1, Username: "user1", Email: "user1@gmail.com"}
+
+	for name, c := range map[string]Codec[User]{
+		"json": NewJSONCodec[User](),
+		"gob":  NewGobCodec[User](),
+	} {
+		data, err := c.Encode(user)
+		assert.NoErrorf(t, err, "%s: Encode() error = %v", name, err)
+		decoded, err := c.Decode(data)
+		assert.NoErrorf(t, err, "%s: Decode() error = %v", name, err)
+		assert.Equalf(t, decoded, user, "%s: Decode() = %v; want %v", name, decoded, user)
+	}
+
+	raw := []byte{0, 1, 2}
+	data, err := NewBytesCodec().Encode(raw)
+	assert.NoError(t, err)
+	assert.Equalf(t, data, raw, "Encode() = %v; want %v", data, raw)
+}
+
+func TestTypedMap(t *testing.T) {
+	data := gomap.NewMap[int, string]()
+	userMap := NewTypedMap[int, User](data, NewJSONCodec[User]())
+
+	err := userMap.Set(1, User{ID: 1, Username: "user1"})
+	assert.NoError(t, err)
+
+	user, ok, err := userMap.Get(1)
+	assert.NoError(t, err)
+	assert.Truef(t, ok, "userMap.Get(1) = %v; want true", ok)
+	assert.Equalf(t, user.Username, "user1", "user.Username = %s; want user1", user.Username)
+
+	_, ok, err = userMap.Get(2)
+	assert.NoError(t, err)
+	assert.Falsef(t, ok, "userMap.Get(2) = %v; want false", ok)
+
+	data.Set(3, "not json")
+	_, ok, err = userMap.Get(3)
+	assert.Truef(t, ok, "userMap.Get(3) = %v; want true", ok)
+	assert.Error(t, err)
+
+	_, err = userMap.Values()
+	assert.Error(t, err)
+
+	userMap.Delete(3)
+	values, err := userMap.Values()
+	assert.NoError(t, err)
+	assert.Lenf(t, values, 1, "len(userMap.Values()) = %d; want 1", len(values))
+}
@@ -0,0 +1,88 @@
+package codec
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/gomap"
+)
+
+// TypedMap stores values of type T in a byte-valued gomap.Map, encoding them
+// with a Codec on the way in and decoding them on the way out.
+type TypedMap[K comparable, T any] interface {
+	Set(key K, value T) error
+	Get(key K) (T, bool, error)
+	Delete(key K)
+	Keys() []K
+	Values() ([]T, error)
+	Len() int
+	TTLKey(key K) time.Duration
+	ExpireKey(key K, ttl time.Duration)
+}
+
+type typedMap[K comparable, T any] struct {
+	data  gomap.Map[K, string]
+	codec Codec[T]
+}
+
+func NewTypedMap[K comparable, T any](data gomap.Map[K, string], codec Codec[T]) TypedMap[K, T] {
+	return &typedMap[K, T]{
+		data:  data,
+		codec: codec,
+	}
+}
+
+func (m *typedMap[K, T]) Set(key K, value T) error {
+	data, err := m.codec.Encode(value)
+	if err != nil {
+		return fmt.Errorf("codec: encode key %v: %w", key, err)
+	}
+	m.data.Set(key, string(data))
+	return nil
+}
+
+func (m *typedMap[K, T]) Get(key K) (value T, ok bool, err error) {
+	data, ok := m.data.Get(key)
+	if !ok {
+		return value, false, nil
+	}
+
+	value, err = m.codec.Decode([]byte(data))
+	if err != nil {
+		return value, true, fmt.Errorf("codec: decode key %v: %w", key, err)
+	}
+	return value, true, nil
+}
+
+func (m *typedMap[K, T]) Delete(key K) {
+	m.data.Delete(key)
+}
+
+func (m *typedMap[K, T]) Keys() []K {
+	return m.data.Keys()
+}
+
+func (m *typedMap[K, T]) Values() ([]T, error) {
+	data := m.data.Values()
+	values := make([]T, 0, len(data))
+	for _, d := range data {
+		value, err := m.codec.Decode([]byte(d))
+		if err != nil {
+			return nil, fmt.Errorf("codec: decode value: %w", err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (m *typedMap[K, T]) Len() int {
+	return m.data.Len()
+}
+
+func (m *typedMap[K, T]) TTLKey(key K) time.Duration {
+	return m.data.TTLKey(key)
+}
+
+func (m *typedMap[K, T]) ExpireKey(key K, ttl time.Duration) {
+	m.data.ExpireKey(key, ttl)
+}
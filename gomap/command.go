@@ -12,13 +12,7 @@ type setCommand[K, V comparable] struct {
 }
 
 func (c *setCommand[K, V]) Execute(mapData *mapData[K, V]) {
-	v, ok := mapData.data[c.key]
-	if !ok {
-		mapData.data[c.key] = newMapValue[V](c.value, 0)
-		return
-	}
-
-	v.SetValue(c.value)
+	mapData.set(c.key, c.value)
 }
 
 type getCommand[K, V comparable] struct {
@@ -27,8 +21,9 @@ type getCommand[K, V comparable] struct {
 }
 
 type getResponse[V comparable] struct {
-	value V
-	found bool
+	value   V
+	version uint64
+	found   bool
 }
 
 func (c *getCommand[K, V]) Execute(mapData *mapData[K, V]) {
@@ -36,7 +31,52 @@ func (c *getCommand[K, V]) Execute(mapData *mapData[K, V]) {
 	if !ok {
 		c.response <- &getResponse[V]{found: false}
 	} else {
-		c.response <- &getResponse[V]{value: v.Value(), found: true}
+		mapData.touch(v)
+		c.response <- &getResponse[V]{value: v.Value(), version: v.Version(), found: true}
+	}
+	close(c.response)
+}
+
+type setWithTTLCommand[K, V comparable] struct {
+	key   K
+	value V
+	ttl   time.Duration
+}
+
+func (c *setWithTTLCommand[K, V]) Execute(mapData *mapData[K, V]) {
+	mapData.set(c.key, c.value)
+	mapData.expireKey(c.key, c.ttl)
+}
+
+type setWithTagsCommand[K, V comparable] struct {
+	key   K
+	value V
+	tags  []string
+}
+
+func (c *setWithTagsCommand[K, V]) Execute(mapData *mapData[K, V]) {
+	mapData.set(c.key, c.value)
+	mapData.tag(c.key, c.tags)
+}
+
+type setIfVersionCommand[K, V comparable] struct {
+	key      K
+	value    V
+	version  uint64
+	response chan error
+}
+
+func (c *setIfVersionCommand[K, V]) Execute(mapData *mapData[K, V]) {
+	var current uint64
+	if v, ok := mapData.data[c.key]; ok {
+		current = v.Version()
+	}
+
+	if current != c.version {
+		c.response <- &VersionConflictError{Expected: c.version, Actual: current}
+	} else {
+		mapData.set(c.key, c.value)
+		c.response <- nil
 	}
 	close(c.response)
 }
@@ -46,7 +86,31 @@ type deleteCommand[K, V comparable] struct {
 }
 
 func (c *deleteCommand[K, V]) Execute(mapData *mapData[K, V]) {
-	delete(mapData.data, c.key)
+	mapData.delete(c.key)
+}
+
+type invalidateTagCommand[K, V comparable] struct {
+	tag      string
+	response chan int
+}
+
+func (c *invalidateTagCommand[K, V]) Execute(mapData *mapData[K, V]) {
+	keys := mapData.tags[c.tag]
+	count := len(keys)
+	for k := range keys {
+		mapData.delete(k)
+	}
+	c.response <- count
+	close(c.response)
+}
+
+type pinCommand[K, V comparable] struct {
+	key    K
+	pinned bool
+}
+
+func (c *pinCommand[K, V]) Execute(mapData *mapData[K, V]) {
+	mapData.pin(c.key, c.pinned)
 }
 
 type getKeysCommand[K, V comparable] struct {
@@ -62,6 +126,24 @@ func (c *getKeysCommand[K, V]) Execute(mapData *mapData[K, V]) {
 	close(c.response)
 }
 
+type lenCommand[K, V comparable] struct {
+	response chan int
+}
+
+func (c *lenCommand[K, V]) Execute(mapData *mapData[K, V]) {
+	c.response <- len(mapData.data)
+	close(c.response)
+}
+
+type statsCommand[K, V comparable] struct {
+	response chan Stats
+}
+
+func (c *statsCommand[K, V]) Execute(mapData *mapData[K, V]) {
+	c.response <- Stats{Keys: len(mapData.data), Pinned: mapData.pinned, Evicted: mapData.evicted}
+	close(c.response)
+}
+
 type getValuesCommand[K, V comparable] struct {
 	response chan []V
 }
@@ -81,11 +163,7 @@ type expireKeyCommand[K, V comparable] struct {
 }
 
 func (c *expireKeyCommand[K, V]) Execute(mapData *mapData[K, V]) {
-	v, ok := mapData.data[c.key]
-	if !ok {
-		return
-	}
-	v.Expire(c.ttl)
+	mapData.expireKey(c.key, c.ttl)
 }
 
 type ttlKeyCommand[K, V comparable] struct {
@@ -102,3 +180,30 @@ func (c *ttlKeyCommand[K, V]) Execute(mapData *mapData[K, V]) {
 	}
 	close(c.response)
 }
+
+type txnCommand[K, V comparable] struct {
+	fn       func(tx Tx[K, V]) error
+	response chan error
+}
+
+func (c *txnCommand[K, V]) Execute(mapData *mapData[K, V]) {
+	c.response <- c.run(mapData)
+	close(c.response)
+}
+
+// run calls the callback and commits its writes, turning a panic into a
+// TxnPanicError instead of letting it take down the command loop.
+func (c *txnCommand[K, V]) run(mapData *mapData[K, V]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &TxnPanicError{Value: r}
+		}
+	}()
+
+	t := newTx(mapData)
+	err = c.fn(t)
+	if err == nil {
+		t.commit()
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+package gomap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBusy is returned by the Try operations when the command queue is full.
+var ErrBusy = errors.New("gomap: command queue is full")
+
+// VersionConflictError is returned by SetIfVersion when the entry was changed,
+// created or deleted since the expected version was read. An Actual of 0 means
+// the key does not exist.
+type VersionConflictError struct {
+	Expected uint64
+	Actual   uint64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("gomap: version conflict: expected %d, actual %d", e.Expected, e.Actual)
+}
+
+// TxnPanicError is returned by Txn when its callback panics. The panic is
+// recovered so that the map keeps serving other callers, and none of the
+// writes staged by the callback are applied.
+type TxnPanicError struct {
+	Value any
+}
+
+func (e *TxnPanicError) Error() string {
+	return fmt.Sprintf("gomap: txn panicked: %v", e.Value)
+}
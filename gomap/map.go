@@ -1,11 +1,26 @@
 package gomap
 
-import "time"
+import (
+	"container/list"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+)
 
 type Map[K, V comparable] interface {
 	Set(key K, value V)
+	SetWithTTL(key K, value V, ttl time.Duration)
+	SetWithTags(key K, value V, tags ...string)
 	Get(key K) (V, bool)
+	TrySet(key K, value V) error
+	TryGet(key K) (V, bool, error)
+	GetWithVersion(key K) (V, uint64, bool)
+	SetIfVersion(key K, value V, version uint64) error
 	Delete(key K)
+	InvalidateTag(tag string) int
+	Pin(key K)
+	Unpin(key K)
 	Keys() []K
 	Values() []V
 	Len() int
@@ -14,14 +29,48 @@ type Map[K, V comparable] interface {
 	ExpireKey(key K, ttl time.Duration)
 	Expire(ttl time.Duration)
 	IsExpired() bool
+	Txn(fn func(tx Tx[K, V]) error) error
+	TryTxn(fn func(tx Tx[K, V]) error) error
+	Stats() Stats
 }
 
-func NewMap[K, V comparable]() Map[K, V] {
+// Stats is a snapshot of a map's size and of the saturation of its command
+// queue.
+type Stats struct {
+	Keys          int
+	Pinned        int
+	Evicted       uint64
+	QueueLength   int
+	QueueCapacity int
+	Rejected      uint64
+}
+
+// Entry describes a key of a map together with its value, remaining TTL and
+// tags.
+type Entry[K, V comparable] struct {
+	Key   K
+	Value V
+	TTL   time.Duration
+	Tags  []string
+}
+
+func NewMap[K, V comparable](opts ...Option) Map[K, V] {
+	o := newOptions(opts)
 	m := &mapData[K, V]{
+		options:        o,
 		data:           make(map[K]*mapValue[V]),
+		tags:           make(map[string]map[K]struct{}),
+		recency:        list.New(),
 		ttl:            0,
 		lastAccessTime: time.Now(),
-		command:        make(chan CommandMap[K, V]),
+		command:        make(chan CommandMap[K, V], o.queueSize),
+	}
+	if o.onEvict != nil {
+		onEvict, ok := o.onEvict.(func(Entry[K, V]))
+		if !ok {
+			panic(fmt.Sprintf("gomap: WithOnEvict callback %T does not match a Map[%T, %T]", o.onEvict, *new(K), *new(V)))
+		}
+		m.onEvict = onEvict
 	}
 
 	go m.executeCommands()
@@ -31,9 +80,17 @@ func NewMap[K, V comparable]() Map[K, V] {
 
 type mapData[K, V comparable] struct {
 	data           map[K]*mapValue[V]
+	tags           map[string]map[K]struct{}
+	recency        *list.List
+	pinned         int
+	evicted        uint64
 	ttl            time.Duration
 	lastAccessTime time.Time
+	version        uint64
+	options        options
+	onEvict        func(Entry[K, V])
 	command        chan CommandMap[K, V]
+	rejected       atomic.Uint64
 }
 
 func (m *mapData[K, V]) Set(key K, value V) {
@@ -51,14 +108,180 @@ func (m *mapData[K, V]) Get(key K) (value V, ok bool) {
 	return value, false
 }
 
+func (m *mapData[K, V]) TrySet(key K, value V) error {
+	return m.trySend(&setCommand[K, V]{key: key, value: value})
+}
+
+func (m *mapData[K, V]) TryGet(key K) (value V, ok bool, err error) {
+	response := make(chan *getResponse[V])
+	err = m.trySend(&getCommand[K, V]{key: key, response: response})
+	if err != nil {
+		return value, false, err
+	}
+
+	res := <-response
+	if res.found {
+		return res.value, true, nil
+	}
+	return value, false, nil
+}
+
+// trySend queues cmd for the event loop without waiting for room in the queue.
+func (m *mapData[K, V]) trySend(cmd CommandMap[K, V]) error {
+	select {
+	case m.command <- cmd:
+		return nil
+	default:
+		m.rejected.Add(1)
+		return ErrBusy
+	}
+}
+
+func (m *mapData[K, V]) set(key K, value V) {
+	m.version++
+
+	v, ok := m.data[key]
+	if !ok {
+		v = newMapValue[V](value, m.options.keyTTL(m.options.defaultTTL), m.version)
+		v.element = m.recency.PushFront(key)
+		m.data[key] = v
+		m.evict()
+		return
+	}
+
+	v.SetValue(value, m.version)
+	m.touch(v)
+}
+
+// touch marks v as the most recently used entry.
+func (m *mapData[K, V]) touch(v *mapValue[V]) {
+	m.recency.MoveToFront(v.element)
+}
+
+// evict removes the least recently used entries that are not pinned until the
+// map fits its capacity again.
+func (m *mapData[K, V]) evict() {
+	if m.options.capacity <= 0 {
+		return
+	}
+
+	e := m.recency.Back()
+	for len(m.data) > m.options.capacity && e != nil {
+		prev := e.Prev()
+		key := e.Value.(K)
+		if v := m.data[key]; !v.pinned {
+			entry := Entry[K, V]{Key: key, Value: v.Value(), TTL: v.Remaining(), Tags: v.tags}
+			m.delete(key)
+			m.evicted++
+			if m.onEvict != nil {
+				m.onEvict(entry)
+			}
+		}
+		e = prev
+	}
+}
+
+func (m *mapData[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	m.command <- &setWithTTLCommand[K, V]{key: key, value: value, ttl: ttl}
+}
+
+func (m *mapData[K, V]) GetWithVersion(key K) (value V, version uint64, ok bool) {
+	response := make(chan *getResponse[V])
+	m.command <- &getCommand[K, V]{key: key, response: response}
+
+	res := <-response
+	if res.found {
+		return res.value, res.version, true
+	}
+	return value, 0, false
+}
+
+func (m *mapData[K, V]) SetIfVersion(key K, value V, version uint64) error {
+	response := make(chan error)
+	m.command <- &setIfVersionCommand[K, V]{key: key, value: value, version: version, response: response}
+	return <-response
+}
+
+func (m *mapData[K, V]) SetWithTags(key K, value V, tags ...string) {
+	m.command <- &setWithTagsCommand[K, V]{key: key, value: value, tags: slices.Clone(tags)}
+}
+
+func (m *mapData[K, V]) tag(key K, tags []string) {
+	v, ok := m.data[key]
+	if !ok {
+		return
+	}
+
+	m.untag(key, v)
+	v.tags = tags
+	for _, tag := range tags {
+		keys, ok := m.tags[tag]
+		if !ok {
+			keys = make(map[K]struct{})
+			m.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+func (m *mapData[K, V]) untag(key K, v *mapValue[V]) {
+	for _, tag := range v.tags {
+		keys := m.tags[tag]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+	v.tags = nil
+}
+
 func (m *mapData[K, V]) Delete(key K) {
 	m.command <- &deleteCommand[K, V]{key: key}
 }
 
 func (m *mapData[K, V]) delete(key K) {
+	v, ok := m.data[key]
+	if !ok {
+		return
+	}
+
+	m.untag(key, v)
+	m.recency.Remove(v.element)
+	if v.pinned {
+		m.pinned--
+	}
 	delete(m.data, key)
 }
 
+func (m *mapData[K, V]) Pin(key K) {
+	m.command <- &pinCommand[K, V]{key: key, pinned: true}
+}
+
+func (m *mapData[K, V]) Unpin(key K) {
+	m.command <- &pinCommand[K, V]{key: key, pinned: false}
+}
+
+func (m *mapData[K, V]) pin(key K, pinned bool) {
+	v, ok := m.data[key]
+	if !ok || v.pinned == pinned {
+		return
+	}
+
+	v.pinned = pinned
+	if pinned {
+		m.pinned++
+	} else {
+		m.pinned--
+		m.evict()
+	}
+}
+
+func (m *mapData[K, V]) InvalidateTag(tag string) int {
+	count := make(chan int)
+	m.command <- &invalidateTagCommand[K, V]{tag: tag, response: count}
+	return <-count
+}
+
 func (m *mapData[K, V]) Keys() []K {
 	keys := make(chan []K)
 	m.command <- &getKeysCommand[K, V]{response: keys}
@@ -72,13 +295,23 @@ func (m *mapData[K, V]) Values() []V {
 }
 
 func (m *mapData[K, V]) Len() int {
-	return len(m.data)
+	length := make(chan int)
+	m.command <- &lenCommand[K, V]{response: length}
+	return <-length
 }
 
 func (m *mapData[K, V]) ExpireKey(key K, ttl time.Duration) {
 	m.command <- &expireKeyCommand[K, V]{key: key, ttl: ttl}
 }
 
+func (m *mapData[K, V]) expireKey(key K, ttl time.Duration) {
+	v, ok := m.data[key]
+	if !ok {
+		return
+	}
+	v.Expire(m.options.keyTTL(ttl))
+}
+
 func (m *mapData[K, V]) Expire(ttl time.Duration) {
 	m.ttl = ttl
 	m.lastAccessTime = time.Now()
@@ -98,6 +331,39 @@ func (m *mapData[K, V]) IsExpired() bool {
 	return m.ttl > 0 && time.Since(m.lastAccessTime) > m.ttl
 }
 
+func (m *mapData[K, V]) Txn(fn func(tx Tx[K, V]) error) error {
+	response := make(chan error)
+	m.command <- &txnCommand[K, V]{fn: fn, response: response}
+	return <-response
+}
+
+// TryTxn is like Txn, but fails with ErrBusy instead of waiting when the
+// command queue is full.
+func (m *mapData[K, V]) TryTxn(fn func(tx Tx[K, V]) error) error {
+	response := make(chan error)
+	err := m.trySend(&txnCommand[K, V]{fn: fn, response: response})
+	if err != nil {
+		return err
+	}
+	return <-response
+}
+
+func (m *mapData[K, V]) Stats() Stats {
+	stats := Stats{
+		QueueLength:   len(m.command),
+		QueueCapacity: cap(m.command),
+		Rejected:      m.rejected.Load(),
+	}
+
+	response := make(chan Stats)
+	m.command <- &statsCommand[K, V]{response: response}
+	res := <-response
+	stats.Keys = res.Keys
+	stats.Pinned = res.Pinned
+	stats.Evicted = res.Evicted
+	return stats
+}
+
 func (m *mapData[K, V]) executeCommands() {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
@@ -114,16 +380,30 @@ func (m *mapData[K, V]) executeCommands() {
 }
 
 func (m *mapData[K, V]) clearExpiredData() {
+	keepPinned := m.options.pinnedExemptFromExpiry && m.pinned > 0
+
 	if m.IsExpired() {
-		m.data = make(map[K]*mapValue[V])
 		m.ttl = 0
 		m.updateLastAccessTime()
+		if !keepPinned {
+			m.data = make(map[K]*mapValue[V])
+			m.tags = make(map[string]map[K]struct{})
+			m.recency.Init()
+			m.pinned = 0
+			return
+		}
+
+		for k, v := range m.data {
+			if !v.pinned {
+				m.delete(k)
+			}
+		}
 		return
 	}
 
 	for k, v := range m.data {
 		if v.IsExpired() {
-			delete(m.data, k)
+			m.delete(k)
 		}
 	}
 }
@@ -136,13 +416,18 @@ type mapValue[V comparable] struct {
 	value          V
 	ttl            time.Duration
 	lastAccessTime time.Time
+	version        uint64
+	tags           []string
+	pinned         bool
+	element        *list.Element
 }
 
-func newMapValue[V comparable](value V, ttl time.Duration) *mapValue[V] {
+func newMapValue[V comparable](value V, ttl time.Duration, version uint64) *mapValue[V] {
 	return &mapValue[V]{
 		value:          value,
 		ttl:            ttl,
 		lastAccessTime: time.Now(),
+		version:        version,
 	}
 }
 
@@ -150,12 +435,26 @@ func (m *mapValue[V]) Value() V {
 	return m.value
 }
 
+func (m *mapValue[V]) Version() uint64 {
+	return m.version
+}
+
 func (m *mapValue[V]) TTL() time.Duration {
 	return m.ttl
 }
 
-func (m *mapValue[V]) SetValue(value V) {
+// Remaining returns the time left before the value expires, or 0 if it has no
+// TTL.
+func (m *mapValue[V]) Remaining() time.Duration {
+	if m.ttl <= 0 {
+		return 0
+	}
+	return max(m.ttl-time.Since(m.lastAccessTime), 1)
+}
+
+func (m *mapValue[V]) SetValue(value V, version uint64) {
 	m.value = value
+	m.version = version
 
 	if m.IsExpired() {
 		m.ttl = 0
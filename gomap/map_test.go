@@ -1,6 +1,7 @@
 package gomap
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -64,3 +65,238 @@ func TestConcurrencyMap(t *testing.T) {
 	time.Sleep(30 * time.Second)
 	assert.Equalf(t, userMap.Len(), 0, "userMap.Len() = %d; want 0", userMap.Len())
 }
+
+func TestMapTxn(t *testing.T) {
+	userMap := NewMap[int, User]()
+	userMap.Set(1, User{ID: 1, Username: "user1"})
+	userMap.Set(2, User{ID: 2, Username: "user2"})
+
+	err := userMap.Txn(func(tx Tx[int, User]) error {
+		user, ok := tx.Get(1)
+		assert.Truef(t, ok, "tx.Get(1) = %v; want true", ok)
+		user.Username = "renamed"
+		tx.Set(1, user)
+		tx.Delete(2)
+		tx.Set(3, User{ID: 3, Username: "user3"})
+		tx.ExpireKey(3, time.Minute)
+
+		_, ok = tx.Get(2)
+		assert.Falsef(t, ok, "tx.Get(2) = %v; want false", ok)
+		user, _ = tx.Get(1)
+		assert.Equalf(t, user.Username, "renamed", "tx.Get(1).Username = %s; want renamed", user.Username)
+		assert.ElementsMatchf(t, tx.Keys(), []int{1, 3}, "tx.Keys() = %v; want [1 3]", tx.Keys())
+		return nil
+	})
+	assert.NoError(t, err)
+
+	user, _ := userMap.Get(1)
+	assert.Equalf(t, user.Username, "renamed", "user.Username = %s; want renamed", user.Username)
+	_, ok := userMap.Get(2)
+	assert.Falsef(t, ok, "userMap.Get(2) = %v; want false", ok)
+	assert.Equalf(t, userMap.TTLKey(3), time.Minute, "userMap.TTLKey(3) = %s; want 1m", userMap.TTLKey(3))
+
+	errAbort := errors.New("abort")
+	err = userMap.Txn(func(tx Tx[int, User]) error {
+		tx.Delete(1)
+		tx.Set(4, User{ID: 4})
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+	_, ok = userMap.Get(1)
+	assert.Truef(t, ok, "userMap.Get(1) = %v; want true", ok)
+	_, ok = userMap.Get(4)
+	assert.Falsef(t, ok, "userMap.Get(4) = %v; want false", ok)
+	assert.Equalf(t, userMap.Len(), 2, "userMap.Len() = %d; want 2", userMap.Len())
+}
+
+func TestMapTxnPanic(t *testing.T) {
+	userMap := NewMap[int, User]()
+	userMap.Set(1, User{ID: 1, Username: "user1"})
+
+	err := userMap.Txn(func(tx Tx[int, User]) error {
+		tx.Delete(1)
+		tx.Set(2, User{ID: 2})
+		panic("boom")
+	})
+	var panicErr *TxnPanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equalf(t, panicErr.Value, "boom", "panicErr.Value = %v; want boom", panicErr.Value)
+
+	_, ok := userMap.Get(1)
+	assert.Truef(t, ok, "userMap.Get(1) = %v; want true", ok)
+	_, ok = userMap.Get(2)
+	assert.Falsef(t, ok, "userMap.Get(2) = %v; want false", ok)
+}
+
+func TestMapSetIfVersion(t *testing.T) {
+	userMap := NewMap[int, User]()
+
+	err := userMap.SetIfVersion(1, User{ID: 1, Username: "user1"}, 0)
+	assert.NoError(t, err)
+
+	user, version, ok := userMap.GetWithVersion(1)
+	assert.Truef(t, ok, "userMap.GetWithVersion(1) = %v; want true", ok)
+	assert.Equalf(t, user.Username, "user1", "user.Username = %s; want user1", user.Username)
+	assert.NotZerof(t, version, "version = %d; want non-zero", version)
+
+	userMap.Set(1, User{ID: 1, Username: "changed"})
+	_, newVersion, _ := userMap.GetWithVersion(1)
+	assert.Greaterf(t, newVersion, version, "newVersion = %d; want > %d", newVersion, version)
+
+	err = userMap.SetIfVersion(1, User{ID: 1, Username: "stale"}, version)
+	var conflict *VersionConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equalf(t, conflict.Actual, newVersion, "conflict.Actual = %d; want %d", conflict.Actual, newVersion)
+
+	err = userMap.SetIfVersion(1, User{ID: 1, Username: "fresh"}, newVersion)
+	assert.NoError(t, err)
+	user, _ = userMap.Get(1)
+	assert.Equalf(t, user.Username, "fresh", "user.Username = %s; want fresh", user.Username)
+
+	userMap.Delete(1)
+	err = userMap.SetIfVersion(1, User{ID: 1}, newVersion)
+	assert.ErrorAs(t, err, &conflict)
+	assert.Zerof(t, conflict.Actual, "conflict.Actual = %d; want 0", conflict.Actual)
+}
+
+func TestMapTags(t *testing.T) {
+	userMap := NewMap[int, User]()
+	userMap.SetWithTags(1, User{ID: 1}, "tenant:a")
+	userMap.SetWithTags(2, User{ID: 2}, "tenant:a", "admins")
+	userMap.SetWithTags(3, User{ID: 3}, "tenant:b")
+	userMap.Set(4, User{ID: 4})
+
+	count := userMap.InvalidateTag("tenant:a")
+	assert.Equalf(t, count, 2, "userMap.InvalidateTag(tenant:a) = %d; want 2", count)
+	assert.Equalf(t, userMap.Len(), 2, "userMap.Len() = %d; want 2", userMap.Len())
+	_, ok := userMap.Get(2)
+	assert.Falsef(t, ok, "userMap.Get(2) = %v; want false", ok)
+
+	count = userMap.InvalidateTag("admins")
+	assert.Equalf(t, count, 0, "userMap.InvalidateTag(admins) = %d; want 0", count)
+
+	userMap.ExpireKey(3, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	count = userMap.InvalidateTag("tenant:b")
+	assert.Equalf(t, count, 0, "userMap.InvalidateTag(tenant:b) = %d; want 0", count)
+
+	userMap.SetWithTags(4, User{ID: 4}, "tenant:c")
+	userMap.SetWithTags(4, User{ID: 4}, "tenant:d")
+	count = userMap.InvalidateTag("tenant:c")
+	assert.Equalf(t, count, 0, "userMap.InvalidateTag(tenant:c) = %d; want 0", count)
+	count = userMap.InvalidateTag("tenant:d")
+	assert.Equalf(t, count, 1, "userMap.InvalidateTag(tenant:d) = %d; want 1", count)
+}
+
+func TestMapTTLOptions(t *testing.T) {
+	userMap := NewMap[int, User](WithDefaultTTL(time.Minute), WithMaxTTL(time.Hour))
+	userMap.Set(1, User{ID: 1})
+	assert.Equalf(t, userMap.TTLKey(1), time.Minute, "userMap.TTLKey(1) = %s; want 1m", userMap.TTLKey(1))
+
+	userMap.SetWithTTL(2, User{ID: 2}, 2*time.Hour)
+	assert.Equalf(t, userMap.TTLKey(2), time.Hour, "userMap.TTLKey(2) = %s; want 1h", userMap.TTLKey(2))
+
+	// The cap applies to keys that would not expire too.
+	userMap.ExpireKey(1, 0)
+	assert.Equalf(t, userMap.TTLKey(1), time.Hour, "userMap.TTLKey(1) = %s; want 1h", userMap.TTLKey(1))
+	cappedMap := NewMap[int, User](WithMaxTTL(time.Hour))
+	cappedMap.Set(1, User{ID: 1})
+	assert.Equalf(t, cappedMap.TTLKey(1), time.Hour, "cappedMap.TTLKey(1) = %s; want 1h", cappedMap.TTLKey(1))
+
+	unboundedMap := NewMap[int, User]()
+	unboundedMap.SetWithTTL(1, User{ID: 1}, time.Minute)
+	unboundedMap.ExpireKey(1, 0)
+	assert.Zerof(t, unboundedMap.TTLKey(1), "unboundedMap.TTLKey(1) = %s; want 0", unboundedMap.TTLKey(1))
+
+	jitterMap := NewMap[int, User](WithTTLJitter(10))
+	for i := 0; i < 100; i++ {
+		jitterMap.SetWithTTL(i, User{ID: int64(i)}, 100*time.Second)
+		ttl := jitterMap.TTLKey(i)
+		assert.GreaterOrEqualf(t, ttl, 90*time.Second, "jitterMap.TTLKey(%d) = %s; want >= 90s", i, ttl)
+		assert.LessOrEqualf(t, ttl, 110*time.Second, "jitterMap.TTLKey(%d) = %s; want <= 110s", i, ttl)
+	}
+}
+
+func TestMapTryOperations(t *testing.T) {
+	userMap := NewMap[int, User](WithCommandQueueSize(1))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go userMap.Txn(func(tx Tx[int, User]) error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	err := userMap.TrySet(1, User{ID: 1})
+	assert.NoError(t, err)
+	err = userMap.TrySet(2, User{ID: 2})
+	assert.ErrorIs(t, err, ErrBusy)
+	_, _, err = userMap.TryGet(1)
+	assert.ErrorIs(t, err, ErrBusy)
+	err = userMap.TryTxn(func(tx Tx[int, User]) error { return nil })
+	assert.ErrorIs(t, err, ErrBusy)
+
+	close(release)
+	user, ok, err := userMap.TryGet(1)
+	for errors.Is(err, ErrBusy) {
+		user, ok, err = userMap.TryGet(1)
+	}
+	assert.NoError(t, err)
+	assert.Truef(t, ok, "userMap.TryGet(1) = %v; want true", ok)
+	assert.Equalf(t, user.ID, int64(1), "user.ID = %d; want 1", user.ID)
+
+	stats := userMap.Stats()
+	assert.Equalf(t, stats.Keys, 1, "stats.Keys = %d; want 1", stats.Keys)
+	assert.Equalf(t, stats.QueueCapacity, 1, "stats.QueueCapacity = %d; want 1", stats.QueueCapacity)
+	assert.GreaterOrEqualf(t, stats.Rejected, uint64(2), "stats.Rejected = %d; want >= 2", stats.Rejected)
+
+	unbuffered := NewMap[int, User](WithCommandQueueSize(-1))
+	unbuffered.Set(1, User{ID: 1})
+	assert.Zerof(t, unbuffered.Stats().QueueCapacity, "unbuffered.Stats().QueueCapacity = %d; want 0", unbuffered.Stats().QueueCapacity)
+}
+
+func TestMapOnEvictTypeMismatch(t *testing.T) {
+	assert.Panics(t, func() {
+		NewMap[int, User](WithCapacity(1), WithOnEvict(func(entry Entry[string, User]) {}))
+	})
+}
+
+func TestMapPin(t *testing.T) {
+	userMap := NewMap[int, User](WithCapacity(2), WithPinnedExemptFromExpiry())
+	userMap.Set(1, User{ID: 1})
+	userMap.Pin(1)
+	userMap.Set(2, User{ID: 2})
+	userMap.Set(3, User{ID: 3})
+
+	_, ok := userMap.Get(1)
+	assert.Truef(t, ok, "userMap.Get(1) = %v; want true", ok)
+	_, ok = userMap.Get(2)
+	assert.Falsef(t, ok, "userMap.Get(2) = %v; want false", ok)
+
+	stats := userMap.Stats()
+	assert.Equalf(t, stats.Keys, 2, "stats.Keys = %d; want 2", stats.Keys)
+	assert.Equalf(t, stats.Pinned, 1, "stats.Pinned = %d; want 1", stats.Pinned)
+	assert.Equalf(t, stats.Evicted, uint64(1), "stats.Evicted = %d; want 1", stats.Evicted)
+
+	userMap.Expire(10 * time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equalf(t, userMap.Keys(), []int{1}, "userMap.Keys() = %v; want [1]", userMap.Keys())
+
+	// A pinned key still expires with its own TTL.
+	userMap.Set(6, User{ID: 6})
+	userMap.Pin(6)
+	userMap.ExpireKey(6, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	_, ok = userMap.Get(6)
+	assert.Falsef(t, ok, "userMap.Get(6) = %v after its TTL; want false", ok)
+	assert.Equalf(t, userMap.Stats().Pinned, 1, "stats.Pinned = %d; want 1", userMap.Stats().Pinned)
+
+	userMap.Unpin(1)
+	userMap.Set(4, User{ID: 4})
+	userMap.Set(5, User{ID: 5})
+	_, ok = userMap.Get(1)
+	assert.Falsef(t, ok, "userMap.Get(1) = %v; want false", ok)
+	assert.Zerof(t, userMap.Stats().Pinned, "stats.Pinned = %d; want 0", userMap.Stats().Pinned)
+}
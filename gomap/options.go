@@ -0,0 +1,110 @@
+package gomap
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+type Option func(*options)
+
+type options struct {
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+	ttlJitter  float64
+	queueSize  int
+	capacity   int
+
+	pinnedExemptFromExpiry bool
+	onEvict                any
+}
+
+// WithDefaultTTL sets the TTL given to keys that are created without one.
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.defaultTTL = ttl
+	}
+}
+
+// WithMaxTTL caps every TTL assigned to a key, including the default TTL. Keys
+// that would not expire are given ttl as well.
+func WithMaxTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.maxTTL = ttl
+	}
+}
+
+// WithTTLJitter randomizes every TTL assigned to a key by up to percent of its
+// length in either direction, so that keys loaded together do not all expire
+// at the same moment.
+func WithTTLJitter(percent float64) Option {
+	return func(o *options) {
+		o.ttlJitter = percent
+	}
+}
+
+// WithCommandQueueSize buffers up to size commands waiting for the map's event
+// loop. Once the queue is full, blocking operations wait for room while the
+// Try variants fail with ErrBusy. A negative size is treated as 0, which
+// leaves the queue unbuffered.
+func WithCommandQueueSize(size int) Option {
+	return func(o *options) {
+		o.queueSize = max(size, 0)
+	}
+}
+
+// WithCapacity limits the map to capacity keys. When a new key would exceed
+// it, the least recently used keys that are not pinned are evicted.
+func WithCapacity(capacity int) Option {
+	return func(o *options) {
+		o.capacity = capacity
+	}
+}
+
+// WithPinnedExemptFromExpiry keeps pinned keys when the whole map expires. A
+// pinned key with a TTL of its own still expires once that TTL elapses.
+func WithPinnedExemptFromExpiry() Option {
+	return func(o *options) {
+		o.pinnedExemptFromExpiry = true
+	}
+}
+
+// WithOnEvict registers fn to be called with every entry evicted to respect
+// the capacity set by WithCapacity. fn runs inside the map's event loop, so it
+// must not call methods of the map. The key and value types of fn must match
+// the ones of the map, otherwise NewMap panics.
+func WithOnEvict[K, V comparable](fn func(entry Entry[K, V])) Option {
+	return func(o *options) {
+		o.onEvict = fn
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// keyTTL returns the TTL actually given to a key when ttl is requested for it.
+// A TTL of zero or less means the key does not expire, unless the map caps
+// TTLs, in which case the key expires after the cap.
+func (o *options) keyTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		if o.maxTTL > 0 {
+			return o.maxTTL
+		}
+		return ttl
+	}
+
+	if o.ttlJitter > 0 {
+		jitter := float64(ttl) * o.ttlJitter / 100
+		ttl += time.Duration(jitter * (2*rand.Float64() - 1))
+		ttl = max(ttl, 1)
+	}
+
+	if o.maxTTL > 0 {
+		ttl = min(ttl, o.maxTTL)
+	}
+	return ttl
+}
@@ -0,0 +1,136 @@
+package gomap
+
+import (
+	"slices"
+	"time"
+)
+
+// Tx is the view of a Map passed to a Txn callback. Reads observe the writes
+// already made through the same Tx, and writes are only applied to the map
+// once the callback returns without an error.
+//
+// A Tx must not be used after its callback returns, and the callback must not
+// call methods of the Map it was started on: it runs inside the map's command
+// loop, so doing so would deadlock.
+type Tx[K, V comparable] interface {
+	Get(key K) (V, bool)
+	Keys() []K
+	Set(key K, value V)
+	SetWithTags(key K, value V, tags ...string)
+	Delete(key K)
+	ExpireKey(key K, ttl time.Duration)
+}
+
+type txWrite[V comparable] struct {
+	value    V
+	hasValue bool
+	deleted  bool
+	ttl      time.Duration
+	hasTTL   bool
+	tags     []string
+	hasTags  bool
+}
+
+type tx[K, V comparable] struct {
+	data   *mapData[K, V]
+	writes map[K]*txWrite[V]
+}
+
+func newTx[K, V comparable](data *mapData[K, V]) *tx[K, V] {
+	return &tx[K, V]{
+		data:   data,
+		writes: make(map[K]*txWrite[V]),
+	}
+}
+
+func (t *tx[K, V]) write(key K) *txWrite[V] {
+	w, ok := t.writes[key]
+	if !ok {
+		w = &txWrite[V]{}
+		t.writes[key] = w
+	}
+	return w
+}
+
+func (t *tx[K, V]) Get(key K) (value V, ok bool) {
+	if w, staged := t.writes[key]; staged {
+		if w.hasValue {
+			return w.value, true
+		}
+		if w.deleted {
+			return value, false
+		}
+	}
+
+	v, ok := t.data.data[key]
+	if !ok {
+		return value, false
+	}
+	return v.Value(), true
+}
+
+// Keys returns the keys of the map as seen by the transaction, including the
+// ones it created and excluding the ones it deleted.
+func (t *tx[K, V]) Keys() []K {
+	keys := make([]K, 0, len(t.data.data))
+	for key := range t.data.data {
+		if w, staged := t.writes[key]; staged && w.deleted && !w.hasValue {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	for key, w := range t.writes {
+		if _, ok := t.data.data[key]; !ok && w.hasValue {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (t *tx[K, V]) Set(key K, value V) {
+	w := t.write(key)
+	w.value = value
+	w.hasValue = true
+}
+
+func (t *tx[K, V]) SetWithTags(key K, value V, tags ...string) {
+	t.Set(key, value)
+	w := t.write(key)
+	w.tags = slices.Clone(tags)
+	w.hasTags = true
+}
+
+func (t *tx[K, V]) Delete(key K) {
+	w := t.write(key)
+	w.deleted = true
+	w.hasValue = false
+	w.hasTTL = false
+	w.hasTags = false
+}
+
+func (t *tx[K, V]) ExpireKey(key K, ttl time.Duration) {
+	if _, ok := t.Get(key); !ok {
+		return
+	}
+
+	w := t.write(key)
+	w.ttl = ttl
+	w.hasTTL = true
+}
+
+func (t *tx[K, V]) commit() {
+	for key, w := range t.writes {
+		if w.deleted {
+			t.data.delete(key)
+		}
+		if w.hasValue {
+			t.data.set(key, w.value)
+		}
+		if w.hasTags {
+			t.data.tag(key, w.tags)
+		}
+		if w.hasTTL {
+			t.data.expireKey(key, w.ttl)
+		}
+	}
+}
@@ -1,44 +1,248 @@
 package hashmap
 
-import "github.com/trinhdaiphuc/go-memcache/gomap"
+import (
+	"time"
+)
 
-type CommandHashMap[K, V comparable] interface {
-	Execute(data *hashMap[K, V])
+type CommandHashMap[K, F, V comparable] interface {
+	Execute(data *hashMap[K, F, V])
 }
 
-type setCommand[K, V comparable] struct {
+type setCommand[K, F, V comparable] struct {
 	key       K
-	keyValues []KeyValue[K, V]
+	keyValues []KeyValue[F, V]
+	response  chan int
 }
 
-func (c *setCommand[K, V]) Execute(hashMap *hashMap[K, V]) {
-	mapData, ok := hashMap.data[c.key]
+func (c *setCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	v, ok := hashMap.data[c.key]
 	if !ok {
-		mapData = gomap.NewMap[K, V]()
-		hashMap.data[c.key] = mapData
+		v = newHashValue[F, V]()
+		hashMap.data[c.key] = v
 	}
 
+	added := 0
 	for _, kv := range c.keyValues {
-		mapData.Set(kv.Key, kv.Value)
+		if _, exists := v.Fields().Get(kv.Key); !exists {
+			added++
+		}
+		v.Fields().Set(kv.Key, kv.Value)
 	}
+	c.response <- added
+	close(c.response)
+}
+
+type updateResponse[V comparable] struct {
+	value V
+	err   error
+}
+
+type updateCommand[K, F, V comparable] struct {
+	key      K
+	field    F
+	fn       func(value V, ok bool) (V, error)
+	response chan *updateResponse[V]
+}
+
+func (c *updateCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	var current V
+	v, ok := hashMap.data[c.key]
+	found := false
+	if ok {
+		current, found = v.Fields().Get(c.field)
+	}
+
+	value, err := c.fn(current, found)
+	if err == nil {
+		if !ok {
+			v = newHashValue[F, V]()
+			hashMap.data[c.key] = v
+		}
+		v.Fields().Set(c.field, value)
+	}
+	c.response <- &updateResponse[V]{value: value, err: err}
+	close(c.response)
+}
+
+type getResponse[F, V comparable] struct {
+	fields map[F]V
+	found  bool
+}
+
+type getCommand[K, F, V comparable] struct {
+	key      K
+	response chan *getResponse[F, V]
+}
+
+func (c *getCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	v, ok := hashMap.data[c.key]
+	if !ok {
+		c.response <- &getResponse[F, V]{found: false}
+	} else {
+		c.response <- &getResponse[F, V]{fields: v.Fields().Map(), found: true}
+	}
+	close(c.response)
+}
+
+type getFieldResponse[V comparable] struct {
+	value V
+	found bool
+}
+
+type getFieldCommand[K, F, V comparable] struct {
+	key      K
+	field    F
+	response chan *getFieldResponse[V]
+}
+
+func (c *getFieldCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	var res getFieldResponse[V]
+	if v, ok := hashMap.data[c.key]; ok {
+		res.value, res.found = v.Fields().Get(c.field)
+	}
+	c.response <- &res
+	close(c.response)
+}
+
+type deleteCommand[K, F, V comparable] struct {
+	key K
+}
+
+func (c *deleteCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	delete(hashMap.data, c.key)
+}
+
+type deleteFieldsCommand[K, F, V comparable] struct {
+	key      K
+	fields   []F
+	response chan int
+}
+
+func (c *deleteFieldsCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	v, ok := hashMap.data[c.key]
+	if !ok {
+		c.response <- 0
+		close(c.response)
+		return
+	}
+
+	count := 0
+	for _, field := range c.fields {
+		if v.Fields().Delete(field) {
+			count++
+		}
+	}
+	if v.Fields().Len() == 0 {
+		delete(hashMap.data, c.key)
+	}
+	c.response <- count
+	close(c.response)
+}
+
+type getKeysCommand[K, F, V comparable] struct {
+	response chan []K
+}
+
+func (c *getKeysCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	keys := make([]K, 0, len(hashMap.data))
+	for k := range hashMap.data {
+		keys = append(keys, k)
+	}
+	c.response <- keys
+	close(c.response)
+}
+
+type getValuesCommand[K, F, V comparable] struct {
+	response chan []map[F]V
+}
+
+func (c *getValuesCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	values := make([]map[F]V, 0, len(hashMap.data))
+	for _, v := range hashMap.data {
+		values = append(values, v.Fields().Map())
+	}
+	c.response <- values
+	close(c.response)
+}
+
+type lenCommand[K, F, V comparable] struct {
+	response chan int
+}
+
+func (c *lenCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	c.response <- len(hashMap.data)
+	close(c.response)
+}
+
+type fieldLenCommand[K, F, V comparable] struct {
+	key      K
+	response chan int
+}
+
+func (c *fieldLenCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	v, ok := hashMap.data[c.key]
+	if !ok {
+		c.response <- 0
+	} else {
+		c.response <- v.Fields().Len()
+	}
+	close(c.response)
+}
+
+type expireCommand[K, F, V comparable] struct {
+	key K
+	ttl time.Duration
 }
 
-type getResponse[K, V comparable] struct {
-	mapValue gomap.Map[K, V]
-	found    bool
+func (c *expireCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	v, ok := hashMap.data[c.key]
+	if !ok {
+		return
+	}
+	v.Expire(c.ttl)
+}
+
+type ttlCommand[K, F, V comparable] struct {
+	key      K
+	response chan time.Duration
+}
+
+func (c *ttlCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	v, ok := hashMap.data[c.key]
+	if !ok {
+		c.response <- 0
+	} else {
+		c.response <- v.TTL()
+	}
+	close(c.response)
+}
+
+type expireFieldCommand[K, F, V comparable] struct {
+	key   K
+	field F
+	ttl   time.Duration
+}
+
+func (c *expireFieldCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	v, ok := hashMap.data[c.key]
+	if !ok {
+		return
+	}
+	v.ExpireField(c.field, c.ttl)
 }
 
-type getCommand[K, V comparable] struct {
+type ttlFieldCommand[K, F, V comparable] struct {
 	key      K
-	response chan *getResponse[K, V]
+	field    F
+	response chan time.Duration
 }
 
-func (c *getCommand[K, V]) Execute(hashMap *hashMap[K, V]) {
-	mapData, ok := hashMap.data[c.key]
+func (c *ttlFieldCommand[K, F, V]) Execute(hashMap *hashMap[K, F, V]) {
+	v, ok := hashMap.data[c.key]
 	if !ok {
-		c.response <- &getResponse[K, V]{found: false}
+		c.response <- 0
 	} else {
-		c.response <- &getResponse[K, V]{mapValue: mapData, found: true}
+		c.response <- v.Fields().TTL(c.field)
 	}
 	close(c.response)
 }
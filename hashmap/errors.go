@@ -0,0 +1,12 @@
+package hashmap
+
+import "errors"
+
+var (
+	ErrNotInteger = errors.New("hash value is not an integer")
+	ErrNotFloat   = errors.New("hash value is not a float")
+	ErrOverflow   = errors.New("increment or decrement would overflow")
+	ErrNaN        = errors.New("increment would produce NaN or Infinity")
+
+	errFieldExists = errors.New("field already exists")
+)
@@ -0,0 +1,181 @@
+package hashmap
+
+import (
+	"slices"
+	"time"
+)
+
+// listpackMaxEntries is the number of fields up to which a hash keeps its
+// fields in a slice. Larger hashes are upgraded to a Go map.
+const listpackMaxEntries = 128
+
+type field[F, V comparable] struct {
+	key            F
+	value          V
+	ttl            time.Duration
+	lastAccessTime time.Time
+}
+
+func (f *field[F, V]) IsExpired() bool {
+	return f.ttl > 0 && time.Since(f.lastAccessTime) > f.ttl
+}
+
+// fields is the field store of a single hash. Small hashes are kept in a
+// compact slice searched linearly, like a Redis listpack; the store switches
+// to a map once it grows past listpackMaxEntries. It is not safe for
+// concurrent use: the hashMap command loop serializes every access.
+type fields[F, V comparable] struct {
+	list  []field[F, V]
+	table map[F]*field[F, V]
+}
+
+func newFields[F, V comparable]() *fields[F, V] {
+	return &fields[F, V]{}
+}
+
+func (f *fields[F, V]) lookup(key F) *field[F, V] {
+	if f.table != nil {
+		return f.table[key]
+	}
+
+	for i := range f.list {
+		if f.list[i].key == key {
+			return &f.list[i]
+		}
+	}
+	return nil
+}
+
+// find returns the live field stored at key, removing it if it has expired.
+func (f *fields[F, V]) find(key F) (*field[F, V], bool) {
+	fd := f.lookup(key)
+	if fd == nil {
+		return nil, false
+	}
+	if fd.IsExpired() {
+		f.Delete(key)
+		return nil, false
+	}
+	return fd, true
+}
+
+func (f *fields[F, V]) Get(key F) (value V, ok bool) {
+	fd, ok := f.find(key)
+	if !ok {
+		return value, false
+	}
+	return fd.value, true
+}
+
+func (f *fields[F, V]) Set(key F, value V) {
+	if fd, ok := f.find(key); ok {
+		fd.value = value
+		fd.lastAccessTime = time.Now()
+		return
+	}
+
+	fd := field[F, V]{key: key, value: value, lastAccessTime: time.Now()}
+	if f.table != nil {
+		f.table[key] = &fd
+		return
+	}
+
+	f.list = append(f.list, fd)
+	if len(f.list) > listpackMaxEntries {
+		f.upgrade()
+	}
+}
+
+func (f *fields[F, V]) upgrade() {
+	f.table = make(map[F]*field[F, V], len(f.list))
+	for i := range f.list {
+		f.table[f.list[i].key] = &f.list[i]
+	}
+	f.list = nil
+}
+
+func (f *fields[F, V]) Delete(key F) bool {
+	if f.table != nil {
+		_, ok := f.table[key]
+		delete(f.table, key)
+		return ok
+	}
+
+	for i := range f.list {
+		if f.list[i].key == key {
+			f.list = slices.Delete(f.list, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fields[F, V]) Len() int {
+	if f.table != nil {
+		return len(f.table)
+	}
+	return len(f.list)
+}
+
+func (f *fields[F, V]) Expire(key F, ttl time.Duration) {
+	fd, ok := f.find(key)
+	if !ok {
+		return
+	}
+	fd.ttl = ttl
+	fd.lastAccessTime = time.Now()
+}
+
+func (f *fields[F, V]) TTL(key F) time.Duration {
+	fd, ok := f.find(key)
+	if !ok {
+		return 0
+	}
+	return fd.ttl
+}
+
+// Map returns a copy of the live fields.
+func (f *fields[F, V]) Map() map[F]V {
+	m := make(map[F]V, f.Len())
+	f.each(func(fd *field[F, V]) {
+		m[fd.key] = fd.value
+	})
+	return m
+}
+
+func (f *fields[F, V]) each(fn func(fd *field[F, V])) {
+	if f.table != nil {
+		for _, fd := range f.table {
+			if !fd.IsExpired() {
+				fn(fd)
+			}
+		}
+		return
+	}
+
+	for i := range f.list {
+		if !f.list[i].IsExpired() {
+			fn(&f.list[i])
+		}
+	}
+}
+
+func (f *fields[F, V]) clearExpiredData() {
+	if f.table != nil {
+		for k, fd := range f.table {
+			if fd.IsExpired() {
+				delete(f.table, k)
+			}
+		}
+		return
+	}
+
+	live := f.list[:0]
+	for _, fd := range f.list {
+		if !fd.IsExpired() {
+			live = append(live, fd)
+		}
+	}
+	clear(f.list[len(live):])
+	f.list = live
+}
@@ -0,0 +1,57 @@
+package hashmap
+
+// Hash is a single hash, holding its fields the same compact way the hashes
+// of a HashMap do. It is meant for callers that store hashes themselves and
+// already serialize every access to them: it is not safe for concurrent use.
+type Hash[F, V comparable] struct {
+	fields *fields[F, V]
+}
+
+func NewHash[F, V comparable]() *Hash[F, V] {
+	return &Hash[F, V]{
+		fields: newFields[F, V](),
+	}
+}
+
+func (h *Hash[F, V]) Get(field F) (V, bool) {
+	return h.fields.Get(field)
+}
+
+// Set stores value in field and reports whether the field did not exist.
+func (h *Hash[F, V]) Set(field F, value V) bool {
+	_, ok := h.fields.Get(field)
+	h.fields.Set(field, value)
+	return !ok
+}
+
+// Update replaces the value of field with the one returned by fn, which
+// receives the current value and whether the field exists. When fn returns an
+// error the hash is left untouched.
+func (h *Hash[F, V]) Update(field F, fn func(value V, ok bool) (V, error)) (V, error) {
+	current, ok := h.fields.Get(field)
+	value, err := fn(current, ok)
+	if err == nil {
+		h.fields.Set(field, value)
+	}
+	return value, err
+}
+
+// Delete removes fields from the hash and returns how many existed.
+func (h *Hash[F, V]) Delete(fields ...F) int {
+	deleted := 0
+	for _, field := range fields {
+		if h.fields.Delete(field) {
+			deleted++
+		}
+	}
+	return deleted
+}
+
+func (h *Hash[F, V]) Len() int {
+	return h.fields.Len()
+}
+
+// Map returns a copy of the fields of the hash.
+func (h *Hash[F, V]) Map() map[F]V {
+	return h.fields.Map()
+}
@@ -2,78 +2,240 @@ package hashmap
 
 import (
 	"time"
-
-	"github.com/trinhdaiphuc/go-memcache/gomap"
 )
 
-type KeyValue[K, V comparable] struct {
-	Key   K
+type KeyValue[F, V comparable] struct {
+	Key   F
 	Value V
 }
 
-type HashMap[K, V comparable] interface {
-	Get(key K) (gomap.Map[K, V], bool)
-	Set(key K, keyValues ...KeyValue[K, V])
+type HashMap[K, F, V comparable] interface {
+	Get(key K) (map[F]V, bool)
+	GetField(key K, field F) (V, bool)
+	Set(key K, keyValues ...KeyValue[F, V]) int
+	SetIfAbsent(key K, field F, value V) bool
+	Update(key K, field F, fn func(value V, ok bool) (V, error)) (V, error)
 	Delete(key K)
+	DeleteFields(key K, fields ...F) int
 	Keys() []K
-	Values() []gomap.Map[K, V]
+	Values() []map[F]V
 	Len() int
+	FieldLen(key K) int
 	TTL(key K) time.Duration
 	Expire(key K, ttl time.Duration)
 	IsExpired() bool
+	IsKeyExpired(key K) bool
+	ExpireField(key K, field F, ttl time.Duration)
+	TTLField(key K, field F) time.Duration
+	PersistField(key K, field F)
 }
 
-type hashMap[K, V comparable] struct {
-	data map[K]gomap.Map[K, V]
+type hashMap[K, F, V comparable] struct {
+	data    map[K]*hashValue[F, V]
+	command chan CommandHashMap[K, F, V]
 }
 
-func NewHashMap[K, V comparable]() HashMap[K, V] {
-	return &hashMap[K, V]{
-		data: make(map[K]gomap.Map[K, V]),
+func NewHashMap[K, F, V comparable]() HashMap[K, F, V] {
+	h := &hashMap[K, F, V]{
+		data:    make(map[K]*hashValue[F, V]),
+		command: make(chan CommandHashMap[K, F, V]),
 	}
+
+	go h.executeCommands()
+
+	return h
+}
+
+func (h *hashMap[K, F, V]) Get(key K) (map[F]V, bool) {
+	response := make(chan *getResponse[F, V])
+	h.command <- &getCommand[K, F, V]{key: key, response: response}
+
+	res := <-response
+	return res.fields, res.found
+}
+
+func (h *hashMap[K, F, V]) GetField(key K, field F) (value V, ok bool) {
+	response := make(chan *getFieldResponse[V])
+	h.command <- &getFieldCommand[K, F, V]{key: key, field: field, response: response}
+
+	res := <-response
+	if res.found {
+		return res.value, true
+	}
+	return value, false
+}
+
+// Set stores keyValues in the hash at key and returns the number of fields
+// that did not exist before.
+func (h *hashMap[K, F, V]) Set(key K, keyValues ...KeyValue[F, V]) int {
+	added := make(chan int)
+	h.command <- &setCommand[K, F, V]{key: key, keyValues: keyValues, response: added}
+	return <-added
+}
+
+// SetIfAbsent stores value in field of the hash at key only if the field does
+// not exist yet, and reports whether it did so.
+func (h *hashMap[K, F, V]) SetIfAbsent(key K, field F, value V) bool {
+	var absent bool
+	_, _ = h.Update(key, field, func(current V, ok bool) (V, error) {
+		if ok {
+			return current, errFieldExists
+		}
+		absent = true
+		return value, nil
+	})
+	return absent
+}
+
+// Update replaces the value of field in the hash at key with the one returned
+// by fn, as a single command. fn receives the current value and whether the
+// field exists; when it returns an error the hash is left untouched. fn runs
+// inside the map's command loop and must not call methods of the map.
+func (h *hashMap[K, F, V]) Update(key K, field F, fn func(value V, ok bool) (V, error)) (V, error) {
+	response := make(chan *updateResponse[V])
+	h.command <- &updateCommand[K, F, V]{key: key, field: field, fn: fn, response: response}
+
+	res := <-response
+	return res.value, res.err
+}
+
+func (h *hashMap[K, F, V]) Delete(key K) {
+	h.command <- &deleteCommand[K, F, V]{key: key}
+}
+
+func (h *hashMap[K, F, V]) DeleteFields(key K, fields ...F) int {
+	count := make(chan int)
+	h.command <- &deleteFieldsCommand[K, F, V]{key: key, fields: fields, response: count}
+	return <-count
+}
+
+func (h *hashMap[K, F, V]) Keys() []K {
+	keys := make(chan []K)
+	h.command <- &getKeysCommand[K, F, V]{response: keys}
+	return <-keys
+}
+
+func (h *hashMap[K, F, V]) Values() []map[F]V {
+	values := make(chan []map[F]V)
+	h.command <- &getValuesCommand[K, F, V]{response: values}
+	return <-values
 }
 
-func (h *hashMap[K, V]) Get(key K) (gomap.Map[K, V], bool) {
-	v, ok := h.data[key]
-	return v, ok
+func (h *hashMap[K, F, V]) Len() int {
+	length := make(chan int)
+	h.command <- &lenCommand[K, F, V]{response: length}
+	return <-length
 }
 
-func (h *hashMap[K, V]) Set(key K, keyValues ...KeyValue[K, V]) {
-	// TODO implement me
-	panic("implement me")
+func (h *hashMap[K, F, V]) FieldLen(key K) int {
+	length := make(chan int)
+	h.command <- &fieldLenCommand[K, F, V]{key: key, response: length}
+	return <-length
 }
 
-func (h *hashMap[K, V]) Delete(key K) {
-	// TODO implement me
-	panic("implement me")
+func (h *hashMap[K, F, V]) TTL(key K) time.Duration {
+	ttl := make(chan time.Duration)
+	h.command <- &ttlCommand[K, F, V]{key: key, response: ttl}
+	return <-ttl
 }
 
-func (h *hashMap[K, V]) Keys() []K {
-	// TODO implement me
-	panic("implement me")
+func (h *hashMap[K, F, V]) Expire(key K, ttl time.Duration) {
+	h.command <- &expireCommand[K, F, V]{key: key, ttl: ttl}
 }
 
-func (h *hashMap[K, V]) Values() []gomap.Map[K, V] {
-	// TODO implement me
-	panic("implement me")
+// IsExpired reports whether the map no longer holds any hash, as happens once
+// the TTLs of all of its hashes have elapsed.
+func (h *hashMap[K, F, V]) IsExpired() bool {
+	return h.Len() == 0
 }
 
-func (h *hashMap[K, V]) Len() int {
-	// TODO implement me
-	panic("implement me")
+// IsKeyExpired reports whether key no longer holds a hash, either because its
+// TTL has elapsed or because it was never set.
+func (h *hashMap[K, F, V]) IsKeyExpired(key K) bool {
+	_, ok := h.Get(key)
+	return !ok
 }
 
-func (h *hashMap[K, V]) TTL(key K) time.Duration {
-	// TODO implement me
-	panic("implement me")
+func (h *hashMap[K, F, V]) ExpireField(key K, field F, ttl time.Duration) {
+	h.command <- &expireFieldCommand[K, F, V]{key: key, field: field, ttl: ttl}
 }
 
-func (h *hashMap[K, V]) Expire(key K, ttl time.Duration) {
-	// TODO implement me
-	panic("implement me")
+func (h *hashMap[K, F, V]) TTLField(key K, field F) time.Duration {
+	ttl := make(chan time.Duration)
+	h.command <- &ttlFieldCommand[K, F, V]{key: key, field: field, response: ttl}
+	return <-ttl
+}
+
+func (h *hashMap[K, F, V]) PersistField(key K, field F) {
+	h.command <- &expireFieldCommand[K, F, V]{key: key, field: field, ttl: 0}
+}
+
+func (h *hashMap[K, F, V]) executeCommands() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case cmd := <-h.command:
+			h.clearExpiredData()
+			cmd.Execute(h)
+		case <-ticker.C:
+			h.clearExpiredData()
+		}
+	}
+}
+
+func (h *hashMap[K, F, V]) clearExpiredData() {
+	for k, v := range h.data {
+		if v.IsExpired() {
+			delete(h.data, k)
+			continue
+		}
+
+		// Only hashes with field TTLs can lose their fields on their own.
+		if v.fieldTTL {
+			v.Fields().clearExpiredData()
+			if v.Fields().Len() == 0 {
+				delete(h.data, k)
+			}
+		}
+	}
+}
+
+type hashValue[F, V comparable] struct {
+	fields         *fields[F, V]
+	ttl            time.Duration
+	lastAccessTime time.Time
+	fieldTTL       bool
+}
+
+func newHashValue[F, V comparable]() *hashValue[F, V] {
+	return &hashValue[F, V]{
+		fields:         newFields[F, V](),
+		lastAccessTime: time.Now(),
+	}
+}
+
+func (h *hashValue[F, V]) Fields() *fields[F, V] {
+	return h.fields
+}
+
+func (h *hashValue[F, V]) TTL() time.Duration {
+	return h.ttl
+}
+
+func (h *hashValue[F, V]) Expire(ttl time.Duration) {
+	h.ttl = ttl
+	h.lastAccessTime = time.Now()
+}
+
+func (h *hashValue[F, V]) ExpireField(field F, ttl time.Duration) {
+	h.fields.Expire(field, ttl)
+	if ttl > 0 {
+		h.fieldTTL = true
+	}
 }
 
-func (h *hashMap[K, V]) IsExpired() bool {
-	// TODO implement me
-	panic("implement me")
+func (h *hashValue[F, V]) IsExpired() bool {
+	return h.ttl > 0 && time.Since(h.lastAccessTime) > h.ttl
 }
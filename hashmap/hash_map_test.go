@@ -0,0 +1,183 @@
+package hashmap
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHashMap(t *testing.T) {
+	hashMap := NewHashMap[string, string, string]()
+	hashMap.Set("user:1", KeyValue[string, string]{Key: "name", Value: "user1"}, KeyValue[string, string]{Key: "email", Value: "user1@gmail.com"})
+	hashMap.Set("user:2", KeyValue[string, string]{Key: "name", Value: "user2"})
+	hashMap.Set("user:3", KeyValue[string, string]{Key: "name", Value: "user3"})
+
+	assert.Equalf(t, hashMap.Len(), 3, "hashMap.Len() = %d; want 3", hashMap.Len())
+	assert.Lenf(t, hashMap.Keys(), 3, "len(hashMap.Keys()) = %d; want 3", len(hashMap.Keys()))
+	assert.Lenf(t, hashMap.Values(), 3, "len(hashMap.Values()) = %d; want 3", len(hashMap.Values()))
+
+	fields, ok := hashMap.Get("user:1")
+	assert.Truef(t, ok, "hashMap.Get(user:1) = %v; want true", ok)
+	assert.Equalf(t, fields, map[string]string{"name": "user1", "email": "user1@gmail.com"}, "hashMap.Get(user:1) = %v", fields)
+	name, ok := hashMap.GetField("user:1", "name")
+	assert.Truef(t, ok, "hashMap.GetField(user:1, name) = %v; want true", ok)
+	assert.Equalf(t, name, "user1", "hashMap.GetField(user:1, name) = %s; want user1", name)
+
+	added := hashMap.Set("user:1", KeyValue[string, string]{Key: "name", Value: "user1"}, KeyValue[string, string]{Key: "age", Value: "20"})
+	assert.Equalf(t, added, 1, "hashMap.Set(user:1) = %d; want 1", added)
+	assert.Equalf(t, hashMap.FieldLen("user:1"), 3, "hashMap.FieldLen(user:1) = %d; want 3", hashMap.FieldLen("user:1"))
+	assert.Falsef(t, hashMap.SetIfAbsent("user:1", "age", "30"), "hashMap.SetIfAbsent(user:1, age) = true; want false")
+	assert.Truef(t, hashMap.SetIfAbsent("user:1", "city", "hcm"), "hashMap.SetIfAbsent(user:1, city) = false; want true")
+	age, _ := hashMap.GetField("user:1", "age")
+	assert.Equalf(t, age, "20", "hashMap.GetField(user:1, age) = %s; want 20", age)
+
+	count := hashMap.DeleteFields("user:3", "name", "missing")
+	assert.Equalf(t, count, 1, "hashMap.DeleteFields(user:3) = %d; want 1", count)
+	_, ok = hashMap.Get("user:3")
+	assert.Falsef(t, ok, "hashMap.Get(user:3) = %v; want false", ok)
+
+	hashMap.Delete("user:1")
+	assert.Equalf(t, hashMap.Len(), 1, "hashMap.Len() = %d; want 1", hashMap.Len())
+	_, ok = hashMap.Get("user:1")
+	assert.Falsef(t, ok, "hashMap.Get(user:1) = %v; want false", ok)
+	assert.Truef(t, hashMap.IsKeyExpired("user:1"), "hashMap.IsKeyExpired(user:1) = false; want true")
+
+	hashMap.Expire("user:2", 100*time.Millisecond)
+	assert.Equalf(t, hashMap.TTL("user:2"), 100*time.Millisecond, "hashMap.TTL(user:2) = %s; want 100ms", hashMap.TTL("user:2"))
+	assert.Falsef(t, hashMap.IsKeyExpired("user:2"), "hashMap.IsKeyExpired(user:2) = true; want false")
+	assert.Falsef(t, hashMap.IsExpired(), "hashMap.IsExpired() = true; want false")
+	time.Sleep(200 * time.Millisecond)
+	_, ok = hashMap.Get("user:2")
+	assert.Falsef(t, ok, "hashMap.Get(user:2) = %v; want false", ok)
+	assert.Zerof(t, hashMap.Len(), "hashMap.Len() = %d; want 0", hashMap.Len())
+	assert.Truef(t, hashMap.IsExpired(), "hashMap.IsExpired() = false after every hash expired; want true")
+}
+
+func TestConcurrencyHashMap(t *testing.T) {
+	hashMap := NewHashMap[string, string, string]()
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(i int) {
+			key := fmt.Sprintf("user:%d", i%100)
+			hashMap.Set(key, KeyValue[string, string]{Key: fmt.Sprintf("field%d", i), Value: "value"})
+			hashMap.Expire(key, time.Second)
+			hashMap.TTL(key)
+			wg.Done()
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equalf(t, hashMap.Len(), 100, "hashMap.Len() = %d; want 100", hashMap.Len())
+	fields, _ := hashMap.Get("user:0")
+	assert.Lenf(t, fields, 10, "len(hashMap.Get(user:0)) = %d; want 10", len(fields))
+}
+
+func TestHashMapLargeHash(t *testing.T) {
+	hashMap := NewHashMap[string, string, int]()
+	for i := 0; i < 2*listpackMaxEntries; i++ {
+		hashMap.Set("counters", KeyValue[string, int]{Key: fmt.Sprintf("field%d", i), Value: i})
+	}
+
+	fields, _ := hashMap.Get("counters")
+	assert.Lenf(t, fields, 2*listpackMaxEntries, "len(hashMap.Get(counters)) = %d; want %d", len(fields), 2*listpackMaxEntries)
+	for _, i := range []int{0, listpackMaxEntries, 2*listpackMaxEntries - 1} {
+		value, ok := hashMap.GetField("counters", fmt.Sprintf("field%d", i))
+		assert.Truef(t, ok, "hashMap.GetField(counters, field%d) = %v; want true", i, ok)
+		assert.Equalf(t, value, i, "hashMap.GetField(counters, field%d) = %d; want %d", i, value, i)
+	}
+}
+
+func TestHashMapFieldTTL(t *testing.T) {
+	hashMap := NewHashMap[string, string, string]()
+	hashMap.Set("session:1", KeyValue[string, string]{Key: "token", Value: "abc"}, KeyValue[string, string]{Key: "user", Value: "user1"})
+
+	hashMap.ExpireField("session:1", "token", time.Minute)
+	assert.Equalf(t, hashMap.TTLField("session:1", "token"), time.Minute, "hashMap.TTLField(session:1, token) = %s; want 1m", hashMap.TTLField("session:1", "token"))
+	hashMap.PersistField("session:1", "token")
+	assert.Zerof(t, hashMap.TTLField("session:1", "token"), "hashMap.TTLField(session:1, token) = %s; want 0", hashMap.TTLField("session:1", "token"))
+
+	hashMap.ExpireField("session:1", "token", 50*time.Millisecond)
+	hashMap.ExpireField("session:1", "user", 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	_, ok := hashMap.Get("session:1")
+	assert.Falsef(t, ok, "hashMap.Get(session:1) = %v; want false", ok)
+	assert.Zerof(t, hashMap.Len(), "hashMap.Len() = %d; want 0", hashMap.Len())
+}
+
+type Setting struct {
+	Enabled bool
+	Limit   int
+}
+
+func TestHashMapDistinctTypes(t *testing.T) {
+	settings := NewHashMap[int64, string, Setting]()
+	settings.Set(1, KeyValue[string, Setting]{Key: "rate-limit", Value: Setting{Enabled: true, Limit: 100}})
+
+	setting, ok := settings.GetField(1, "rate-limit")
+	assert.Truef(t, ok, "settings.GetField(1, rate-limit) = %v; want true", ok)
+	assert.Equalf(t, setting.Limit, 100, "setting.Limit = %d; want 100", setting.Limit)
+	assert.Equalf(t, settings.Keys(), []int64{1}, "settings.Keys() = %v; want [1]", settings.Keys())
+}
+
+func TestHashMapIncrBy(t *testing.T) {
+	hashMap := NewHashMap[string, string, string]()
+
+	value, err := IncrBy(hashMap, "user:1", "visits", 5)
+	assert.NoError(t, err)
+	assert.Equalf(t, value, int64(5), "IncrBy(user:1, visits, 5) = %d; want 5", value)
+	value, err = IncrBy(hashMap, "user:1", "visits", -7)
+	assert.NoError(t, err)
+	assert.Equalf(t, value, int64(-2), "IncrBy(user:1, visits, -7) = %d; want -2", value)
+
+	hashMap.Set("user:1", KeyValue[string, string]{Key: "name", Value: "user1"}, KeyValue[string, string]{Key: "max", Value: "9223372036854775807"})
+	_, err = IncrBy(hashMap, "user:1", "name", 1)
+	assert.ErrorIs(t, err, ErrNotInteger)
+	_, err = IncrBy(hashMap, "user:1", "max", 1)
+	assert.ErrorIs(t, err, ErrOverflow)
+	for _, value := range []string{"+5", "007", "-0", " 1", ""} {
+		hashMap.Set("user:1", KeyValue[string, string]{Key: "odd", Value: value})
+		_, err = IncrBy(hashMap, "user:1", "odd", 1)
+		assert.ErrorIsf(t, err, ErrNotInteger, "IncrBy of %q did not fail", value)
+	}
+
+	score, err := IncrByFloat(hashMap, "user:1", "score", 10.5)
+	assert.NoError(t, err)
+	score, err = IncrByFloat(hashMap, "user:1", "score", 0.1)
+	assert.NoError(t, err)
+	assert.InDeltaf(t, score, 10.6, 1e-9, "IncrByFloat(user:1, score, 0.1) = %f; want 10.6", score)
+	stored, _ := hashMap.GetField("user:1", "score")
+	assert.Equalf(t, stored, "10.6", "hashMap.GetField(user:1, score) = %s; want 10.6", stored)
+
+	_, err = IncrByFloat(hashMap, "user:1", "name", 1)
+	assert.ErrorIs(t, err, ErrNotFloat)
+	hashMap.Set("user:1", KeyValue[string, string]{Key: "huge", Value: "1.7e308"})
+	_, err = IncrByFloat(hashMap, "user:1", "huge", 1.7e308)
+	assert.ErrorIs(t, err, ErrNaN)
+}
+
+func TestHash(t *testing.T) {
+	h := NewHash[string, string]()
+
+	assert.Truef(t, h.Set("name", "user1"), "h.Set(name) = false; want true")
+	assert.Falsef(t, h.Set("name", "user2"), "h.Set(name) = true; want false")
+	value, ok := h.Get("name")
+	assert.Truef(t, ok && value == "user2", "h.Get(name) = %s, %t; want user2, true", value, ok)
+
+	visits, err := HashIncrBy(h, "visits", 3)
+	assert.NoError(t, err)
+	assert.Equalf(t, visits, int64(3), "HashIncrBy(visits, 3) = %d; want 3", visits)
+	_, err = HashIncrByFloat(h, "name", 1)
+	assert.ErrorIs(t, err, ErrNotFloat)
+
+	assert.Equal(t, h.Map(), map[string]string{"name": "user2", "visits": "3"})
+	deleted := h.Delete("name", "missing")
+	assert.Equalf(t, deleted, 1, "h.Delete(name, missing) = %d; want 1", deleted)
+	assert.Equalf(t, h.Len(), 1, "h.Len() = %d; want 1", h.Len())
+}
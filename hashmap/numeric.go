@@ -0,0 +1,92 @@
+package hashmap
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// IncrBy atomically adds delta to the integer stored in field of the hash at
+// key, creating the hash and the field when needed, and returns the new value.
+func IncrBy[K, F comparable](h HashMap[K, F, string], key K, field F, delta int64) (int64, error) {
+	var result int64
+	_, err := h.Update(key, field, incrBy(delta, &result))
+	return result, err
+}
+
+// IncrByFloat atomically adds delta to the float stored in field of the hash
+// at key, creating the hash and the field when needed, and returns the new
+// value.
+func IncrByFloat[K, F comparable](h HashMap[K, F, string], key K, field F, delta float64) (float64, error) {
+	var result float64
+	_, err := h.Update(key, field, incrByFloat(delta, &result))
+	return result, err
+}
+
+// HashIncrBy is IncrBy for a single Hash.
+func HashIncrBy[F comparable](h *Hash[F, string], field F, delta int64) (int64, error) {
+	var result int64
+	_, err := h.Update(field, incrBy(delta, &result))
+	return result, err
+}
+
+// HashIncrByFloat is IncrByFloat for a single Hash.
+func HashIncrByFloat[F comparable](h *Hash[F, string], field F, delta float64) (float64, error) {
+	var result float64
+	_, err := h.Update(field, incrByFloat(delta, &result))
+	return result, err
+}
+
+// ParseInt parses s the way Redis's string2ll does: unlike strconv.ParseInt,
+// it rejects a leading '+', leading zeros and "-0", so that only the integers
+// Redis itself formats are read as integers.
+func ParseInt(s string) (int64, bool) {
+	digits := strings.TrimPrefix(s, "-")
+	if digits == "" || digits[0] < '1' || digits[0] > '9' {
+		return 0, s == "0"
+	}
+
+	value, err := strconv.ParseInt(s, 10, 64)
+	return value, err == nil
+}
+
+// incrBy returns an update function adding delta to an integer value, which
+// also stores the new value in result.
+func incrBy(delta int64, result *int64) func(value string, ok bool) (string, error) {
+	return func(value string, ok bool) (string, error) {
+		var current int64
+		if ok {
+			if current, ok = ParseInt(value); !ok {
+				return "", ErrNotInteger
+			}
+		}
+
+		if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+			return "", ErrOverflow
+		}
+
+		*result = current + delta
+		return strconv.FormatInt(*result, 10), nil
+	}
+}
+
+// incrByFloat returns an update function adding delta to a float value, which
+// also stores the new value in result.
+func incrByFloat(delta float64, result *float64) func(value string, ok bool) (string, error) {
+	return func(value string, ok bool) (string, error) {
+		var current float64
+		if ok {
+			var err error
+			current, err = strconv.ParseFloat(value, 64)
+			if err != nil || math.IsNaN(current) || math.IsInf(current, 0) {
+				return "", ErrNotFloat
+			}
+		}
+
+		*result = current + delta
+		if math.IsNaN(*result) || math.IsInf(*result, 0) {
+			return "", ErrNaN
+		}
+		return strconv.FormatFloat(*result, 'f', -1, 64), nil
+	}
+}
@@ -0,0 +1,119 @@
+package hyperloglog
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	h := New()
+	assert.Truef(t, h.IsSparse(), "New().IsSparse() = false; want true")
+	assert.Zerof(t, h.Count(), "New().Count() = %d; want 0", h.Count())
+
+	changed := h.Add("a", "b", "c", "d", "e", "f", "g")
+	assert.Truef(t, changed, "h.Add(a, ..., g) = false; want true")
+	changed = h.Add("a", "b")
+	assert.Falsef(t, changed, "h.Add(a, b) = true; want false")
+	assert.Equalf(t, h.Count(), uint64(7), "h.Count() = %d; want 7", h.Count())
+}
+
+func TestHyperLogLogError(t *testing.T) {
+	for _, n := range []int{100, 1000, 10000, 100000, 1000000} {
+		h := New()
+		for i := 0; i < n; i++ {
+			h.Add(strconv.Itoa(i))
+		}
+
+		// Allow 4 standard errors of 0.81%.
+		count := h.Count()
+		relative := math.Abs(float64(count)-float64(n)) / float64(n)
+		assert.Truef(t, relative < 4*0.0081, "h.Count() = %d after %d elements; error %.4f", count, n, relative)
+	}
+}
+
+func TestHyperLogLogEncodings(t *testing.T) {
+	h := New()
+	for i := 0; i < 100; i++ {
+		h.Add(strconv.Itoa(i))
+	}
+	assert.Truef(t, h.IsSparse(), "h.IsSparse() = false after 100 elements; want true")
+	sparseCount := h.Count()
+
+	// A sparse HyperLogLog turns dense as it grows.
+	for i := 100; i < 5000; i++ {
+		h.Add(strconv.Itoa(i))
+	}
+	assert.Falsef(t, h.IsSparse(), "h.IsSparse() = true after 5000 elements; want false")
+	assert.Lenf(t, h.Bytes(), denseSize, "len(h.Bytes()) = %d; want %d", len(h.Bytes()), denseSize)
+
+	sparse := New()
+	for i := 0; i < 100; i++ {
+		sparse.Add(strconv.Itoa(i))
+	}
+	parsed, err := Parse(sparse.Bytes())
+	assert.NoError(t, err)
+	assert.Equalf(t, parsed.Count(), sparseCount, "parsed.Count() = %d; want %d", parsed.Count(), sparseCount)
+
+	dense, err := Parse(h.Bytes())
+	assert.NoError(t, err)
+	assert.Equalf(t, dense.Count(), h.Count(), "dense.Count() = %d; want %d", dense.Count(), h.Count())
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		[]byte("not a hyperloglog"),
+		append([]byte("HYLL\x00\x00\x00\x00"), make([]byte, 100)...),
+		[]byte("HYLL\x02\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80\x7f\xff"),
+	} {
+		_, err := Parse(data)
+		assert.ErrorIsf(t, err, ErrInvalid, "Parse(%q) did not fail", data)
+	}
+
+	// A sparse encoding describing too few registers.
+	data := []byte("HYLL\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80\x00")
+	_, err := Parse(data)
+	assert.ErrorIsf(t, err, ErrCorrupt, "Parse(%q) did not report a corrupted HyperLogLog", data)
+}
+
+func TestCountCraftedRegisters(t *testing.T) {
+	// A dense encoding with an invalid cache and every register set to 63,
+	// above the hashBits+1 an element can set it to.
+	data := []byte("HYLL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80")
+	for len(data) < denseSize {
+		data = append(data, 0xff)
+	}
+
+	h, err := Parse(data)
+	assert.NoError(t, err)
+	assert.NotPanicsf(t, func() { h.Count() }, "h.Count() panicked")
+	assert.NotPanicsf(t, func() { Count(h, New()) }, "Count(h, New()) panicked")
+}
+
+func TestMerge(t *testing.T) {
+	a, b := New(), New()
+	for i := 0; i < 3000; i++ {
+		a.Add(strconv.Itoa(i))
+		b.Add(strconv.Itoa(i + 2000))
+	}
+
+	union := Count(a, b)
+	assert.InDeltaf(t, float64(union), 5000, 5000*4*0.0081, "Count(a, b) = %d; want about 5000", union)
+
+	a.Merge(b)
+	assert.Equalf(t, a.Count(), union, "a.Count() = %d after a.Merge(b); want %d", a.Count(), union)
+	assert.Falsef(t, a.IsSparse(), "a.IsSparse() = true after a.Merge(b); want false")
+}
+
+func TestMurmurHash64A(t *testing.T) {
+	// Every length of the tail of the key must be hashed differently.
+	seen := make(map[uint64]bool)
+	for _, key := range []string{"", "a", "ab", "abc", "abcd", "abcde", "abcdef", "abcdefg", "abcdefgh", "abcdefghi"} {
+		hash := murmurHash64A([]byte(key), hashSeed)
+		assert.Falsef(t, seen[hash], "murmurHash64A(%q) = %x collides", key, hash)
+		seen[hash] = true
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type AppendHandler struct {
+}
+
+func NewAppendHandler() Handler {
+	return &AppendHandler{}
+}
+
+// Handle implements APPEND key value, which replies with the length of the
+// string after the append.
+func (h *AppendHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(APPEND)
+	}
+
+	key := argString(args[0])
+	var length int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, _, err := tx.GetString(key)
+		if err != nil {
+			return err
+		}
+		value += argString(args[1])
+		if len(value) > maxStringSize {
+			return errStringTooLong
+		}
+
+		length = len(value)
+		setStringKeepTTL(tx, key, value)
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(length)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import "testing"
+
+func TestAppendAndStrLen(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"creates and extends": {
+			{"STRLEN k", ":0\r\n"},
+			{"APPEND k Hello", ":5\r\n"},
+			{`APPEND k " World"`, ":11\r\n"},
+			{"GET k", "$11\r\nHello World\r\n"},
+			{"STRLEN k", ":11\r\n"},
+		},
+		"counts bytes": {
+			{`APPEND k "caf\xc3\xa9"`, ":5\r\n"},
+			{`APPEND k "\x00"`, ":6\r\n"},
+			{"STRLEN k", ":6\r\n"},
+			{"GET k", "$6\r\ncaf\xc3\xa9\x00\r\n"},
+		},
+		"keeps the time to live": {
+			{"SET k v EX 100", "+OK\r\n"},
+			{"APPEND k w", ":2\r\n"},
+			{"TTL k", ":100\r\n"},
+		},
+		"wrong type": {
+			{"RPUSH k a", ":1\r\n"},
+			{"APPEND k v", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"STRLEN k", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+		"wrong number of arguments": {
+			{"APPEND k", "-ERR wrong number of arguments for 'append' command\r\n"},
+			{"STRLEN k v", "-ERR wrong number of arguments for 'strlen' command\r\n"},
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/hashmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+func newWrongNumberOfArgsError(cmd string) resp.Expression {
+	return resp.NewErrorExpression("ERR wrong number of arguments for '" + strings.ToLower(cmd) + "' command")
+}
+
+// newErrorExpression replies with err, which is a generic error unless it
+// carries its own prefix, as WRONGTYPE does.
+func newErrorExpression(err error) resp.Expression {
+	if errors.Is(err, keyspace.ErrWrongType) {
+		return resp.NewErrorExpression(err.Error())
+	}
+	return resp.NewErrorExpression("ERR " + err.Error())
+}
+
+func newSyntaxError() resp.Expression {
+	return resp.NewErrorExpression("ERR syntax error")
+}
+
+func newNotIntegerError() resp.Expression {
+	return resp.NewErrorExpression("ERR value is not an integer or out of range")
+}
+
+func newNotFloatError() resp.Expression {
+	return resp.NewErrorExpression("ERR value is not a valid float")
+}
+
+// maxRandomCount bounds the negative count of HRANDFIELD and SRANDMEMBER,
+// which asks for that many possibly repeated elements in a single reply.
+const maxRandomCount = 1 << 24
+
+// argRandomCount parses the count of HRANDFIELD and SRANDMEMBER.
+func argRandomCount(arg resp.Expression) (int64, resp.Expression) {
+	count, ok := argInt(arg)
+	if !ok {
+		return 0, newNotIntegerError()
+	}
+	if count < -maxRandomCount {
+		return 0, resp.NewErrorExpression(fmt.Sprintf("ERR value is out of range, must be between %d and %d", -maxRandomCount, math.MaxInt64))
+	}
+	return count, nil
+}
+
+func argString(arg resp.Expression) string {
+	value, _ := arg.Value().(string)
+	return value
+}
+
+func argStrings(args []resp.Expression) []string {
+	values := make([]string, 0, len(args))
+	for _, arg := range args {
+		values = append(values, argString(arg))
+	}
+	return values
+}
+
+func argInt(arg resp.Expression) (int64, bool) {
+	return parseInt(argString(arg))
+}
+
+// parseInt parses s the way Redis's string2ll does, as HINCRBY parses the
+// values it increments.
+func parseInt(s string) (int64, bool) {
+	return hashmap.ParseInt(s)
+}
+
+func argFloat(arg resp.Expression) (float64, bool) {
+	value, err := strconv.ParseFloat(argString(arg), 64)
+	return value, err == nil
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/bitmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type BitCountHandler struct {
+}
+
+func NewBitCountHandler() Handler {
+	return &BitCountHandler{}
+}
+
+// Handle implements BITCOUNT key [start end [BYTE | BIT]].
+func (h *BitCountHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(BITCOUNT)
+	}
+	if len(args) == 2 || len(args) > 4 {
+		return newSyntaxError()
+	}
+
+	start, end := int64(0), int64(-1)
+	bitUnit := false
+	if len(args) >= 3 {
+		var startOK, endOK bool
+		start, startOK = argInt(args[1])
+		end, endOK = argInt(args[2])
+		if !startOK || !endOK {
+			return newNotIntegerError()
+		}
+	}
+	if len(args) == 4 {
+		var ok bool
+		if bitUnit, ok = argBitUnit(args[3]); !ok {
+			return newSyntaxError()
+		}
+	}
+
+	var count int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, _, err := tx.GetBytes(argString(args[0]))
+		if err != nil {
+			return err
+		}
+
+		first, last, ok := bitRange(start, end, bitUnit, len(value))
+		if ok && !(start < 0 && end < 0 && start > end) {
+			count = bitmap.Count(value, first, last)
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(count)
+}
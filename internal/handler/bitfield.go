@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/bitmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type bitFieldOp struct {
+	name     string
+	field    bitmap.Field
+	offset   uint64
+	value    int64
+	overflow bitmap.Overflow
+}
+
+type BitFieldHandler struct {
+}
+
+func NewBitFieldHandler() Handler {
+	return &BitFieldHandler{}
+}
+
+// Handle implements BITFIELD key [GET type offset] [SET type offset value]
+// [INCRBY type offset increment] [OVERFLOW WRAP | SAT | FAIL] ..., which
+// runs its operations in order in a single update of the keyspace. OVERFLOW
+// applies to the SET and INCRBY operations following it, which reply with
+// nil when they fail.
+func (h *BitFieldHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(BITFIELD)
+	}
+
+	var ops []bitFieldOp
+	overflow := bitmap.Wrap
+	writes := false
+	for i := 1; i < len(args); {
+		name := strings.ToUpper(argString(args[i]))
+		if name == "OVERFLOW" {
+			if i+1 == len(args) {
+				return newSyntaxError()
+			}
+			switch strings.ToUpper(argString(args[i+1])) {
+			case "WRAP":
+				overflow = bitmap.Wrap
+			case "SAT":
+				overflow = bitmap.Sat
+			case "FAIL":
+				overflow = bitmap.Fail
+			default:
+				return resp.NewErrorExpression("ERR Invalid OVERFLOW type specified")
+			}
+			i += 2
+			continue
+		}
+
+		n := 3
+		if name == "GET" {
+			n = 2
+		} else if name != "SET" && name != "INCRBY" {
+			return newSyntaxError()
+		}
+		if i+n >= len(args) {
+			return newSyntaxError()
+		}
+
+		op := bitFieldOp{name: name, overflow: overflow}
+		var ok bool
+		if op.field, ok = argBitField(args[i+1]); !ok {
+			return resp.NewErrorExpression("ERR Invalid bitfield type. Use something like i16 u8. Note that u64 is not supported but i64 is.")
+		}
+		if op.offset, ok = argBitFieldOffset(args[i+2], op.field); !ok {
+			return newBitOffsetError()
+		}
+		if n == 3 {
+			if op.value, ok = argInt(args[i+3]); !ok {
+				return newNotIntegerError()
+			}
+			writes = true
+		}
+		ops = append(ops, op)
+		i += n + 1
+	}
+
+	key := argString(args[0])
+	replies := make([]resp.Expression, 0, len(ops))
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, _, err := tx.GetBytes(key)
+		if err != nil {
+			return err
+		}
+
+		b := value
+		for _, op := range ops {
+			current := op.field.Get(b, op.offset)
+			if op.name == "GET" {
+				replies = append(replies, resp.NewIntegerExpression(int(current)))
+				continue
+			}
+
+			var result int64
+			var ok bool
+			if op.name == "SET" {
+				result, ok = op.field.Add(op.value, 0, op.overflow)
+			} else {
+				result, ok = op.field.Add(current, op.value, op.overflow)
+			}
+			// The string grows to hold the field even if the write fails.
+			b = bitmap.Grow(b, op.offset+uint64(op.field.Bits))
+			if !ok {
+				replies = append(replies, resp.NewNullBulkStringExpression())
+				continue
+			}
+
+			b = op.field.Set(b, op.offset, result)
+			// SET replies with the value it replaced, INCRBY with the new one.
+			if op.name == "SET" {
+				result = current
+			}
+			replies = append(replies, resp.NewIntegerExpression(int(result)))
+		}
+
+		// The fields are written in place, so the string is only stored
+		// again when it grows.
+		if writes && len(b) != len(value) {
+			setBytesKeepTTL(tx, key, b)
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewArrayExpression(replies...)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/bitmap"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// maxBitOffset is the number of bits in a string of the largest size.
+const maxBitOffset = maxStringSize * 8
+
+func newBitOffsetError() resp.Expression {
+	return resp.NewErrorExpression("ERR bit offset is not an integer or out of range")
+}
+
+func argBitOffset(arg resp.Expression) (uint64, bool) {
+	offset, ok := argInt(arg)
+	return uint64(offset), ok && offset >= 0 && offset < maxBitOffset
+}
+
+// argBitUnit parses the BYTE or BIT unit of a BITCOUNT or BITPOS range, and
+// reports whether the range is counted in bits.
+func argBitUnit(arg resp.Expression) (bitUnit bool, ok bool) {
+	switch strings.ToUpper(argString(arg)) {
+	case "BYTE":
+		return false, true
+	case "BIT":
+		return true, true
+	}
+	return false, false
+}
+
+// bitRange turns the start and end of a range of a string of size bytes,
+// counted in bytes or in bits and negative from its end, into the offsets of
+// its first and last bits. ok is false if the range is empty.
+func bitRange(start, end int64, bitUnit bool, size int) (first, last uint64, ok bool) {
+	total := int64(size)
+	if bitUnit {
+		total *= 8
+	}
+	if start < 0 {
+		start = max(total+start, 0)
+	}
+	if end < 0 {
+		end = max(total+end, 0)
+	}
+	end = min(end, total-1)
+	if start > end {
+		return 0, 0, false
+	}
+
+	if bitUnit {
+		return uint64(start), uint64(end), true
+	}
+	return uint64(start) * 8, uint64(end)*8 + 7, true
+}
+
+// argBitField parses the type of a BITFIELD field, such as i16 or u8.
+func argBitField(arg resp.Expression) (bitmap.Field, bool) {
+	s := argString(arg)
+	if len(s) < 2 || (s[0] != 'i' && s[0] != 'I' && s[0] != 'u' && s[0] != 'U') {
+		return bitmap.Field{}, false
+	}
+
+	n, err := strconv.Atoi(s[1:])
+	field := bitmap.Field{Signed: s[0] == 'i' || s[0] == 'I', Bits: n}
+	return field, err == nil && field.Valid()
+}
+
+// argBitFieldOffset parses the offset of a BITFIELD field, given in bits or,
+// prefixed with "#", in multiples of the field's width.
+func argBitFieldOffset(arg resp.Expression, field bitmap.Field) (uint64, bool) {
+	s := argString(arg)
+	if !strings.HasPrefix(s, "#") {
+		return argBitOffset(arg)
+	}
+
+	n, err := strconv.ParseInt(s[1:], 10, 64)
+	if err != nil || n < 0 || n >= maxBitOffset/int64(field.Bits) {
+		return 0, false
+	}
+	return uint64(n) * uint64(field.Bits), true
+}
@@ -0,0 +1,207 @@
+package handler
+
+import "testing"
+
+func TestSetBitAndGetBit(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"sets and clears": {
+			{"GETBIT k 7", ":0\r\n"},
+			{"SETBIT k 7 1", ":0\r\n"},
+			{"GET k", "$1\r\n\x01\r\n"},
+			{"GETBIT k 7", ":1\r\n"},
+			{"SETBIT k 7 0", ":1\r\n"},
+			{"GETBIT k 7", ":0\r\n"},
+			{"GETBIT k 1000", ":0\r\n"},
+		},
+		"modifies a string in place": {
+			{"SET k a", "+OK\r\n"},
+			{"SETBIT k 6 1", ":0\r\n"},
+			{"GET k", "$1\r\nc\r\n"},
+			{"SETBIT k 6 0", ":1\r\n"},
+			{"GET k", "$1\r\na\r\n"},
+		},
+		"grows with zero bytes": {
+			{"SETBIT k 100 1", ":0\r\n"},
+			{"STRLEN k", ":13\r\n"},
+			{"GETBIT k 100", ":1\r\n"},
+			{"SETBIT k 0 1", ":0\r\n"},
+			{"STRLEN k", ":13\r\n"},
+			{"BITCOUNT k", ":2\r\n"},
+		},
+		"keeps the time to live": {
+			{"SET k v EX 100", "+OK\r\n"},
+			{"SETBIT k 0 1", ":0\r\n"},
+			{"TTL k", ":100\r\n"},
+			{"SETBIT k 100 1", ":0\r\n"},
+			{"TTL k", ":100\r\n"},
+		},
+		"invalid arguments": {
+			{"SETBIT k 0 2", "-ERR bit is not an integer or out of range\r\n"},
+			{"SETBIT k 0 x", "-ERR bit is not an integer or out of range\r\n"},
+			{"SETBIT k -1 1", "-ERR bit offset is not an integer or out of range\r\n"},
+			{"SETBIT k 4294967296 1", "-ERR bit offset is not an integer or out of range\r\n"},
+			{"GETBIT k x", "-ERR bit offset is not an integer or out of range\r\n"},
+			{"SETBIT k 0", "-ERR wrong number of arguments for 'setbit' command\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"wrong type": {
+			{"RPUSH k a", ":1\r\n"},
+			{"SETBIT k 0 1", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"GETBIT k 0", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
+
+func TestBitCount(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"ranges": {
+			{"SET k foobar", "+OK\r\n"},
+			{"BITCOUNT k", ":26\r\n"},
+			{"BITCOUNT k 0 0", ":4\r\n"},
+			{"BITCOUNT k 1 1", ":6\r\n"},
+			{"BITCOUNT k 1 1 BYTE", ":6\r\n"},
+			{"BITCOUNT k 5 30 BIT", ":17\r\n"},
+			{"BITCOUNT k -2 -1", ":7\r\n"},
+			{"BITCOUNT k -1 -2", ":0\r\n"},
+			{"BITCOUNT k 10 20", ":0\r\n"},
+		},
+		"missing key": {
+			{"BITCOUNT k", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"BITCOUNT k 1", "-ERR syntax error\r\n"},
+			{"BITCOUNT k 0 1 WORD", "-ERR syntax error\r\n"},
+			{"BITCOUNT k a 1", "-ERR value is not an integer or out of range\r\n"},
+		},
+	})
+}
+
+func TestBitPos(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"ranges": {
+			{`SET k "\x00\xff\xf0"`, "+OK\r\n"},
+			{"BITPOS k 1 0", ":8\r\n"},
+			{"BITPOS k 1 2", ":16\r\n"},
+			{"BITPOS k 1 2 -1 BYTE", ":16\r\n"},
+			{"BITPOS k 1 7 15 BIT", ":8\r\n"},
+			{"BITPOS k 1 7 -3 BIT", ":8\r\n"},
+			{"BITPOS k 0 1", ":20\r\n"},
+			{"BITPOS k 1 5", ":-1\r\n"},
+		},
+		"zero bits past the end": {
+			{`SET k "\xff\xff"`, "+OK\r\n"},
+			{"BITPOS k 0", ":16\r\n"},
+			{"BITPOS k 0 0 -1", ":-1\r\n"},
+		},
+		"missing key": {
+			{"BITPOS k 0", ":0\r\n"},
+			{"BITPOS k 1", ":-1\r\n"},
+		},
+		"invalid arguments": {
+			{"BITPOS k 2", "-ERR The bit argument must be 1 or 0.\r\n"},
+			{"BITPOS k 1 a", "-ERR value is not an integer or out of range\r\n"},
+			{"BITPOS k 1 0 1 WORD", "-ERR syntax error\r\n"},
+		},
+	})
+}
+
+func TestBitOp(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"operations": {
+			{"SET a foobar", "+OK\r\n"},
+			{"SET b abcdef", "+OK\r\n"},
+			{"BITOP AND d a b", ":6\r\n"},
+			{"GET d", "$6\r\n`bc`ab\r\n"},
+			{"BITOP OR d a b", ":6\r\n"},
+			{"GET d", "$6\r\ngoofev\r\n"},
+			{"BITOP XOR d a missing", ":6\r\n"},
+			{"GET d", "$6\r\nfoobar\r\n"},
+			{`SET c "\x0f"`, "+OK\r\n"},
+			{"BITOP NOT d c", ":1\r\n"},
+			{"GET d", "$1\r\n\xf0\r\n"},
+		},
+		"does not share the sources": {
+			{"SET a foobar", "+OK\r\n"},
+			{"BITOP AND d a", ":6\r\n"},
+			{"SETBIT d 0 1", ":0\r\n"},
+			{"GET a", "$6\r\nfoobar\r\n"},
+		},
+		"replaces the destination": {
+			{"SET d v EX 100", "+OK\r\n"},
+			{"SET a foobar", "+OK\r\n"},
+			{"BITOP OR d a", ":6\r\n"},
+			{"TTL d", ":-1\r\n"},
+			{"BITOP OR d missing", ":0\r\n"},
+			{"EXISTS d", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"BITOP NOT d a b", "-ERR BITOP NOT must be called with a single source key.\r\n"},
+			{"BITOP NAND d a", "-ERR syntax error\r\n"},
+			{"RPUSH l a", ":1\r\n"},
+			{"BITOP OR d l", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
+
+func TestBitField(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"get, set and incrby": {
+			{"BITFIELD k INCRBY i5 100 1 GET u4 0", "*2\r\n:1\r\n:0\r\n"},
+			{"BITFIELD k SET u8 #1 200 GET u8 8", "*2\r\n:0\r\n:200\r\n"},
+			{"BITFIELD k SET u8 8 7", "*1\r\n:200\r\n"},
+			{"BITFIELD k", "*0\r\n"},
+		},
+		"overflow wrap": {
+			{"BITFIELD k SET i8 0 127", "*1\r\n:0\r\n"},
+			{"BITFIELD k INCRBY i8 0 1", "*1\r\n:-128\r\n"},
+			{"BITFIELD k OVERFLOW WRAP INCRBY u8 0 -1", "*1\r\n:127\r\n"},
+			{"BITFIELD k SET u2 0 5", "*1\r\n:1\r\n"},
+			{"BITFIELD k GET u2 0", "*1\r\n:1\r\n"},
+		},
+		"overflow sat": {
+			{"BITFIELD k INCRBY u2 100 1 OVERFLOW SAT INCRBY u2 102 1", "*2\r\n:1\r\n:1\r\n"},
+			{"BITFIELD k INCRBY u2 100 1 OVERFLOW SAT INCRBY u2 102 1", "*2\r\n:2\r\n:2\r\n"},
+			{"BITFIELD k INCRBY u2 100 1 OVERFLOW SAT INCRBY u2 102 1", "*2\r\n:3\r\n:3\r\n"},
+			{"BITFIELD k INCRBY u2 100 1 OVERFLOW SAT INCRBY u2 102 1", "*2\r\n:0\r\n:3\r\n"},
+			{"BITFIELD k OVERFLOW SAT SET i8 200 -1000", "*1\r\n:0\r\n"},
+			{"BITFIELD k GET i8 200", "*1\r\n:-128\r\n"},
+		},
+		"overflow fail": {
+			{"BITFIELD k SET u2 0 3", "*1\r\n:0\r\n"},
+			{"BITFIELD k OVERFLOW FAIL INCRBY u2 0 1 INCRBY u2 0 -1", "*2\r\n$-1\r\n:2\r\n"},
+			{"BITFIELD k OVERFLOW FAIL SET i4 4 8", "*1\r\n$-1\r\n"},
+			{"BITFIELD k GET u2 0 GET i4 4", "*2\r\n:2\r\n:0\r\n"},
+		},
+		"grows even when a write fails": {
+			{"BITFIELD k OVERFLOW FAIL SET u2 14 4", "*1\r\n$-1\r\n"},
+			{"STRLEN k", ":2\r\n"},
+		},
+		"reads without creating the key": {
+			{"BITFIELD k GET u8 0", "*1\r\n:0\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"keeps the time to live": {
+			{"SET k v EX 100", "+OK\r\n"},
+			{"BITFIELD k SET u8 0 1", "*1\r\n:118\r\n"},
+			{"TTL k", ":100\r\n"},
+			{"BITFIELD k SET u8 100 1", "*1\r\n:0\r\n"},
+			{"TTL k", ":100\r\n"},
+		},
+		"invalid arguments": {
+			{"BITFIELD k OVERFLOW BAD GET u8 0", "-ERR Invalid OVERFLOW type specified\r\n"},
+			{"BITFIELD k GET u64 0", "-ERR Invalid bitfield type. Use something like i16 u8. Note that u64 is not supported but i64 is.\r\n"},
+			{"BITFIELD k GET i65 0", "-ERR Invalid bitfield type. Use something like i16 u8. Note that u64 is not supported but i64 is.\r\n"},
+			{"BITFIELD k GET u8 -1", "-ERR bit offset is not an integer or out of range\r\n"},
+			{"BITFIELD k SET i8 0 x", "-ERR value is not an integer or out of range\r\n"},
+			{"BITFIELD k GET u8", "-ERR syntax error\r\n"},
+			{"BITFIELD k OVERFLOW", "-ERR syntax error\r\n"},
+			{"BITFIELD k FOO u8 0", "-ERR syntax error\r\n"},
+			{"BITFIELD", "-ERR wrong number of arguments for 'bitfield' command\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"wrong type": {
+			{"RPUSH k a", ":1\r\n"},
+			{"BITFIELD k GET u8 0", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/bitmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type BitOpHandler struct {
+}
+
+func NewBitOpHandler() Handler {
+	return &BitOpHandler{}
+}
+
+// Handle implements BITOP AND | OR | XOR | NOT destkey key [key ...], which
+// stores the result at destkey and replies with its length. Missing keys are
+// read as empty strings, padded with zero bytes like the shorter strings.
+func (h *BitOpHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 3 {
+		return newWrongNumberOfArgsError(BITOP)
+	}
+
+	var op func(srcs ...[]byte) []byte
+	switch strings.ToUpper(argString(args[0])) {
+	case "AND":
+		op = bitmap.And
+	case "OR":
+		op = bitmap.Or
+	case "XOR":
+		op = bitmap.Xor
+	case "NOT":
+		if len(args) != 3 {
+			return resp.NewErrorExpression("ERR BITOP NOT must be called with a single source key.")
+		}
+		op = func(srcs ...[]byte) []byte {
+			return bitmap.Not(srcs[0])
+		}
+	default:
+		return newSyntaxError()
+	}
+
+	destination := argString(args[1])
+	var length int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		srcs := make([][]byte, 0, len(args)-2)
+		for _, key := range argStrings(args[2:]) {
+			value, _, err := tx.GetBytes(key)
+			if err != nil {
+				return err
+			}
+			srcs = append(srcs, value)
+		}
+
+		result := op(srcs...)
+		length = len(result)
+		if length == 0 {
+			tx.Delete(destination)
+		} else {
+			tx.SetBytes(destination, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(length)
+}
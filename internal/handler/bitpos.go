@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/bitmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type BitPosHandler struct {
+}
+
+func NewBitPosHandler() Handler {
+	return &BitPosHandler{}
+}
+
+// Handle implements BITPOS key bit [start [end [BYTE | BIT]]]. Without an
+// end, the string is considered padded with zero bits on its right, so that
+// looking for a 0 in a string of 1s finds the bit past its end.
+func (h *BitPosHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 {
+		return newWrongNumberOfArgsError(BITPOS)
+	}
+	if len(args) > 5 {
+		return newSyntaxError()
+	}
+
+	bit, ok := argInt(args[1])
+	if !ok {
+		return newNotIntegerError()
+	}
+	if bit != 0 && bit != 1 {
+		return resp.NewErrorExpression("ERR The bit argument must be 1 or 0.")
+	}
+
+	start, end := int64(0), int64(-1)
+	endGiven, bitUnit := len(args) >= 4, false
+	if len(args) >= 3 {
+		if start, ok = argInt(args[2]); !ok {
+			return newNotIntegerError()
+		}
+	}
+	if endGiven {
+		if end, ok = argInt(args[3]); !ok {
+			return newNotIntegerError()
+		}
+	}
+	if len(args) == 5 {
+		if bitUnit, ok = argBitUnit(args[4]); !ok {
+			return newSyntaxError()
+		}
+	}
+
+	var pos int64
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, found, err := tx.GetBytes(argString(args[0]))
+		if err != nil {
+			return err
+		}
+		if !found {
+			// A missing key is an empty string padded with zero bits.
+			if bit == 1 {
+				pos = -1
+			}
+			return nil
+		}
+
+		first, last, ok := bitRange(start, end, bitUnit, len(value))
+		if !ok {
+			pos = -1
+			return nil
+		}
+		pos = bitmap.Pos(value, int(bit), first, last)
+		if pos < 0 && bit == 0 && !endGiven {
+			pos = int64(last) + 1
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(int(pos))
+}
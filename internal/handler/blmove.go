@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type BLMoveHandler struct {
+}
+
+func NewBLMoveHandler() Handler {
+	return &BLMoveHandler{}
+}
+
+// Handle implements BLMOVE source destination LEFT|RIGHT LEFT|RIGHT timeout,
+// the blocking variant of LMOVE.
+func (h *BLMoveHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 5 {
+		return newWrongNumberOfArgsError(BLMOVE)
+	}
+
+	fromLeft, fromOK := argSide(args[2])
+	toLeft, toOK := argSide(args[3])
+	if !fromOK || !toOK {
+		return newSyntaxError()
+	}
+
+	timeout, errReply := argTimeout(args[4])
+	if errReply != nil {
+		return errReply
+	}
+
+	source, destination := argString(args[0]), argString(args[1])
+	return ctx.Blocked.block(ctx, []string{source}, timeout, func(tx keyspace.Tx, key string) resp.Expression {
+		value, ok, err := moveValue(tx, source, destination, fromLeft, toLeft)
+		if err != nil {
+			return newErrorExpression(err)
+		}
+		if !ok {
+			return nil
+		}
+
+		ctx.Blocked.serve(tx, destination)
+		return resp.NewBulkStringExpression(value)
+	})
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingMove(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"moves without blocking": {
+			{"RPUSH src a b", ":2\r\n"},
+			{"BLMOVE src dst LEFT RIGHT 0", "$1\r\na\r\n"},
+			{"LRANGE dst 0 -1", "*1\r\n$1\r\na\r\n"},
+			{"BLMOVE src src RIGHT LEFT 0", "$1\r\nb\r\n"},
+			{"LRANGE src 0 -1", "*1\r\n$1\r\nb\r\n"},
+		},
+		"times out": {
+			{"BLMOVE src dst LEFT RIGHT 0.01", "*-1\r\n"},
+			{"EXISTS src dst", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"BLMOVE src dst UP RIGHT 0", "-ERR syntax error\r\n"},
+			{"BLMOVE src dst LEFT RIGHT -1", "-ERR timeout is negative\r\n"},
+			{"BLMOVE src dst LEFT RIGHT", "-ERR wrong number of arguments for 'blmove' command\r\n"},
+		},
+		"wrong type": {
+			{"RPUSH src a", ":1\r\n"},
+			{"SET dst v", "+OK\r\n"},
+			{"BLMOVE src dst LEFT LEFT 0", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"LLEN src", ":1\r\n"},
+			{"BLMOVE dst src LEFT LEFT 0", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
+
+func TestBlockingMoveWakeUp(t *testing.T) {
+	ctx := newTestContext()
+
+	moved := callAsync(t, ctx, "BLMOVE", "src", "dst", "RIGHT", "LEFT", "0")
+	waitBlocked(t, ctx, "src", 1)
+	popped := callAsync(t, ctx, "BLPOP", "dst", "0")
+	waitBlocked(t, ctx, "dst", 1)
+
+	assert.Equal(t, ":1\r\n", call(t, ctx, "LPUSH", "src", "v"))
+	assert.Equal(t, "$1\r\nv\r\n", receive(t, moved))
+	assert.Equal(t, "*2\r\n$3\r\ndst\r\n$1\r\nv\r\n", receive(t, popped))
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// popFunc pops from the list at key inside tx and returns the reply of the
+// blocking command, or nil if there is no list at key.
+type popFunc func(tx keyspace.Tx, key string) resp.Expression
+
+type waiter struct {
+	keys  []string
+	pop   popFunc
+	reply chan resp.Expression
+}
+
+// BlockedClients queues, for every list key, the clients blocked until a
+// value is pushed to it. Clients are served in the order they blocked in.
+type BlockedClients struct {
+	mu      sync.Mutex
+	waiters map[string][]*waiter
+}
+
+func NewBlockedClients() *BlockedClients {
+	return &BlockedClients{
+		waiters: make(map[string][]*waiter),
+	}
+}
+
+func (b *BlockedClients) add(w *waiter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, key := range w.keys {
+		b.waiters[key] = append(b.waiters[key], w)
+	}
+}
+
+// remove unqueues w and reports whether it was still waiting, that is whether
+// it has not been served.
+func (b *BlockedClients) remove(w *waiter) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.unlink(w)
+}
+
+func (b *BlockedClients) unlink(w *waiter) bool {
+	found := false
+	for _, key := range w.keys {
+		queue := b.waiters[key]
+		i := slices.Index(queue, w)
+		if i < 0 {
+			continue
+		}
+
+		found = true
+		if len(queue) == 1 {
+			delete(b.waiters, key)
+		} else {
+			b.waiters[key] = slices.Delete(queue, i, i+1)
+		}
+	}
+	return found
+}
+
+// next unqueues the client that has been blocked on key for the longest time.
+func (b *BlockedClients) next(key string) *waiter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	queue := b.waiters[key]
+	if len(queue) == 0 {
+		return nil
+	}
+
+	w := queue[0]
+	b.unlink(w)
+	return w
+}
+
+// serve hands the values of the list at key to the clients blocked on it. It
+// must be called inside the transaction that pushed the values, so that a
+// client blocking concurrently either sees them or is queued before they are
+// served.
+func (b *BlockedClients) serve(tx keyspace.Tx, key string) {
+	for {
+		l, ok, _ := tx.GetList(key)
+		if !ok || l.Len() == 0 {
+			return
+		}
+
+		w := b.next(key)
+		if w == nil {
+			return
+		}
+		w.reply <- w.pop(tx, key)
+	}
+}
+
+// block pops from the first non-empty list of keys, or else waits until a
+// value is pushed to one of them, timeout elapses or the client disconnects.
+// A zero timeout waits forever. It replies with a null array if nothing was
+// popped.
+func (b *BlockedClients) block(ctx Context, keys []string, timeout time.Duration, pop popFunc) resp.Expression {
+	w := &waiter{keys: keys, pop: pop, reply: make(chan resp.Expression, 1)}
+
+	var reply resp.Expression
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		for _, key := range keys {
+			if reply = pop(tx, key); reply != nil {
+				return nil
+			}
+		}
+		b.add(w)
+		return nil
+	})
+	if reply != nil {
+		return reply
+	}
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case reply = <-w.reply:
+		return reply
+	case <-expired:
+	case <-ctx.Done:
+	}
+
+	// The client may have been served between the timeout, or its
+	// disconnection, and its removal from the queues. The value was popped
+	// already, so it is replied like Redis replies to a client whose
+	// connection is closing.
+	if !b.remove(w) {
+		return <-w.reply
+	}
+	return resp.NewNullArrayExpression()
+}
+
+// argTimeout parses the timeout of a blocking command, given in seconds.
+func argTimeout(arg resp.Expression) (time.Duration, resp.Expression) {
+	seconds, ok := argFloat(arg)
+	if !ok || math.IsNaN(seconds) || seconds > float64(math.MaxInt64/time.Second) {
+		return 0, resp.NewErrorExpression("ERR timeout is not a float or out of range")
+	}
+	if seconds < 0 {
+		return 0, resp.NewErrorExpression("ERR timeout is negative")
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/list"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// BlockingPopHandler implements BLPOP and BRPOP, which reply with the key
+// popped from and its value, or a null array once the timeout elapses.
+type BlockingPopHandler struct {
+	cmd string
+	pop func(l *list.List, count int) []string
+}
+
+func NewBLPopHandler() Handler {
+	return &BlockingPopHandler{cmd: BLPOP, pop: (*list.List).PopFront}
+}
+
+func NewBRPopHandler() Handler {
+	return &BlockingPopHandler{cmd: BRPOP, pop: (*list.List).PopBack}
+}
+
+func (h *BlockingPopHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	timeout, errReply := argTimeout(args[len(args)-1])
+	if errReply != nil {
+		return errReply
+	}
+
+	keys := argStrings(args[:len(args)-1])
+	return ctx.Blocked.block(ctx, keys, timeout, func(tx keyspace.Tx, key string) resp.Expression {
+		l, ok, err := tx.GetList(key)
+		if err != nil {
+			return newErrorExpression(err)
+		}
+		if !ok {
+			return nil
+		}
+
+		popped := h.pop(l, 1)
+		return resp.NewBulkStringArrayExpression([]string{key, popped[0]})
+	})
+}
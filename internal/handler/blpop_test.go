@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// callAsync runs the command made of args in the background and returns the
+// channel its reply is sent to.
+func callAsync(t *testing.T, ctx Context, args ...string) <-chan string {
+	reply := make(chan string, 1)
+	go func() {
+		reply <- call(t, ctx, args...)
+	}()
+	return reply
+}
+
+// waitBlocked waits until n clients are blocked on key.
+func waitBlocked(t *testing.T, ctx Context, key string, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ctx.Blocked.mu.Lock()
+		blocked := len(ctx.Blocked.waiters[key])
+		ctx.Blocked.mu.Unlock()
+		if blocked == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d clients blocked on %s; want %d", blocked, key, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func receive(t *testing.T, reply <-chan string) string {
+	t.Helper()
+
+	select {
+	case r := <-reply:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reply")
+		return ""
+	}
+}
+
+func TestBlockingPop(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"pops without blocking": {
+			{"RPUSH b x", ":1\r\n"},
+			{"BLPOP a b 0", "*2\r\n$1\r\nb\r\n$1\r\nx\r\n"},
+			{"RPUSH a x y", ":2\r\n"},
+			{"BRPOP a 0", "*2\r\n$1\r\na\r\n$1\r\ny\r\n"},
+		},
+		"times out": {
+			{"BLPOP a 0.01", "*-1\r\n"},
+			{"BRPOP a b 0.01", "*-1\r\n"},
+			{"EXISTS a b", ":0\r\n"},
+		},
+		"pops from the first key holding a list": {
+			{"RPUSH b x", ":1\r\n"},
+			{"RPUSH c y z", ":2\r\n"},
+			{"BRPOP a c b 0", "*2\r\n$1\r\nc\r\n$1\r\nz\r\n"},
+			{"BLPOP a b c 0", "*2\r\n$1\r\nb\r\n$1\r\nx\r\n"},
+			{"EXISTS b", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"BLPOP a -1", "-ERR timeout is negative\r\n"},
+			{"BLPOP a x", "-ERR timeout is not a float or out of range\r\n"},
+			{"BLPOP a", "-ERR wrong number of arguments for 'blpop' command\r\n"},
+			{"BRPOP a", "-ERR wrong number of arguments for 'brpop' command\r\n"},
+		},
+		"wrong type": {
+			{"SET a v", "+OK\r\n"},
+			{"BLPOP a 0", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"BRPOP missing a 0", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
+
+func TestBlockingPopWakeUp(t *testing.T) {
+	ctx := newTestContext()
+
+	first := callAsync(t, ctx, "BLPOP", "a", "b", "0")
+	waitBlocked(t, ctx, "b", 1)
+	second := callAsync(t, ctx, "BRPOP", "b", "0")
+	waitBlocked(t, ctx, "b", 2)
+
+	assert.Equal(t, ":2\r\n", call(t, ctx, "RPUSH", "b", "x", "y"))
+	assert.Equal(t, "*2\r\n$1\r\nb\r\n$1\r\nx\r\n", receive(t, first))
+	assert.Equal(t, "*2\r\n$1\r\nb\r\n$1\r\ny\r\n", receive(t, second))
+	assert.Equal(t, ":0\r\n", call(t, ctx, "EXISTS", "b"))
+	waitBlocked(t, ctx, "a", 0)
+}
+
+func TestBlockingPopFairness(t *testing.T) {
+	ctx := newTestContext()
+
+	var replies []<-chan string
+	for i := 0; i < 3; i++ {
+		replies = append(replies, callAsync(t, ctx, "BLPOP", "k", "0"))
+		waitBlocked(t, ctx, "k", i+1)
+	}
+
+	// Each push serves the client blocked for the longest time.
+	for _, value := range []string{"a", "b", "c"} {
+		assert.Equal(t, ":1\r\n", call(t, ctx, "RPUSH", "k", value))
+		assert.Equal(t, "*2\r\n$1\r\nk\r\n$1\r\n"+value+"\r\n", receive(t, replies[0]))
+		replies = replies[1:]
+	}
+	waitBlocked(t, ctx, "k", 0)
+}
+
+func TestBlockingPopTimeoutUnblocks(t *testing.T) {
+	ctx := newTestContext()
+
+	reply := callAsync(t, ctx, "BLPOP", "k", "0.05")
+	waitBlocked(t, ctx, "k", 1)
+	assert.Equal(t, "*-1\r\n", receive(t, reply))
+	waitBlocked(t, ctx, "k", 0)
+
+	// The value pushed after the timeout stays in the list.
+	assert.Equal(t, ":1\r\n", call(t, ctx, "RPUSH", "k", "v"))
+	assert.Equal(t, ":1\r\n", call(t, ctx, "LLEN", "k"))
+}
+
+func TestBlockingPopDisconnect(t *testing.T) {
+	ctx := newTestContext()
+
+	done := make(chan struct{})
+	reply := callAsync(t, ctx.WithDone(done), "BLPOP", "k", "0")
+	waitBlocked(t, ctx, "k", 1)
+	close(done)
+
+	assert.Equal(t, "*-1\r\n", receive(t, reply))
+	waitBlocked(t, ctx, "k", 0)
+	assert.Equal(t, ":1\r\n", call(t, ctx, "LPUSH", "k", "v"))
+	assert.Equal(t, ":1\r\n", call(t, ctx, "LLEN", "k"))
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type DelHandler struct {
+}
+
+func NewDelHandler() Handler {
+	return &DelHandler{}
+}
+
+// Handle implements DEL key [key ...], which removes keys of any type and
+// replies with the number of keys removed.
+func (h *DelHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(DEL)
+	}
+
+	deleted := 0
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		for _, key := range argStrings(args) {
+			if tx.Delete(key) {
+				deleted++
+			}
+		}
+		return nil
+	})
+	return resp.NewIntegerExpression(deleted)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type ExistsHandler struct {
+}
+
+func NewExistsHandler() Handler {
+	return &ExistsHandler{}
+}
+
+// Handle implements EXISTS key [key ...]. A key given several times is
+// counted as many times.
+func (h *ExistsHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(EXISTS)
+	}
+
+	count := 0
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		for _, key := range argStrings(args) {
+			if tx.Exists(key) {
+				count++
+			}
+		}
+		return nil
+	})
+	return resp.NewIntegerExpression(count)
+}
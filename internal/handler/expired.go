@@ -3,20 +3,56 @@ package handler
 import (
 	"time"
 
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
 	"github.com/trinhdaiphuc/go-memcache/resp"
 )
 
+// ExpiredHandler implements EXPIRE and EXPIREAT, which set the time to live of
+// a key of any type in seconds or as a Unix time.
 type ExpiredHandler struct {
+	cmd string
+	opt expireOption
 }
 
 func NewExpiredHandler() Handler {
-	return &ExpiredHandler{}
+	return &ExpiredHandler{cmd: EXPIRED, opt: expireOptions["EX"]}
+}
+
+func NewExpireAtHandler() Handler {
+	return &ExpiredHandler{cmd: EXPIREAT, opt: expireOptions["EXAT"]}
 }
 
 func (e *ExpiredHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
-	key := args[0].Value().(string)
-	ttl := args[1].Value().(int)
-	ctx.Map.ExpireKey(key, time.Second*time.Duration(ttl))
-	ctx.Has.Expire(key, time.Second*time.Duration(ttl))
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(e.cmd)
+	}
+
+	key := argString(args[0])
+	value, ok := argInt(args[1])
+	if !ok {
+		return newNotIntegerError()
+	}
+	var expireAt time.Time
+	if value > 0 {
+		var errReply resp.Expression
+		expireAt, errReply = argExpireTime(e.cmd, e.opt, args[1])
+		if errReply != nil {
+			return errReply
+		}
+	}
+
+	// A key set to expire at a time already past is removed right away.
+	var found bool
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		if expireAt.After(time.Now()) {
+			found = tx.ExpireAt(key, expireAt)
+		} else {
+			found = tx.Delete(key)
+		}
+		return nil
+	})
+	if !found {
+		return resp.NewIntegerExpression(0)
+	}
 	return resp.NewIntegerExpression(1)
 }
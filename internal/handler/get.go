@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
 	"github.com/trinhdaiphuc/go-memcache/resp"
 )
 
@@ -12,8 +13,21 @@ func NewGetHandler() Handler {
 }
 
 func (g *GetHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
-	key := args[0].Value().(string)
-	value, ok := ctx.Map.Get(key)
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(GET)
+	}
+
+	key := argString(args[0])
+	var value string
+	var ok bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		var err error
+		value, ok, err = tx.GetString(key)
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
 	if !ok {
 		return resp.NewNullBulkStringExpression()
 	}
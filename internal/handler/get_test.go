@@ -0,0 +1,23 @@
+package handler
+
+import "testing"
+
+func TestGet(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"missing key": {
+			{"GET k", "$-1\r\n"},
+		},
+		"string": {
+			{"SET k v", "+OK\r\n"},
+			{"GET k", "$1\r\nv\r\n"},
+		},
+		"wrong number of arguments": {
+			{"GET", "-ERR wrong number of arguments for 'get' command\r\n"},
+			{"GET a b", "-ERR wrong number of arguments for 'get' command\r\n"},
+		},
+		"wrong type": {
+			{"LPUSH k v", ":1\r\n"},
+			{"GET k", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
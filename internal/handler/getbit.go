@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/bitmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type GetBitHandler struct {
+}
+
+func NewGetBitHandler() Handler {
+	return &GetBitHandler{}
+}
+
+func (h *GetBitHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(GETBIT)
+	}
+
+	offset, ok := argBitOffset(args[1])
+	if !ok {
+		return newBitOffsetError()
+	}
+
+	var bit int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, _, err := tx.GetBytes(argString(args[0]))
+		bit = bitmap.GetBit(value, offset)
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(bit)
+}
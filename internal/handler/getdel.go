@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type GetDelHandler struct {
+}
+
+func NewGetDelHandler() Handler {
+	return &GetDelHandler{}
+}
+
+func (h *GetDelHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(GETDEL)
+	}
+
+	key := argString(args[0])
+	var value string
+	var found bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		var err error
+		value, found, err = tx.GetString(key)
+		if found {
+			tx.Delete(key)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !found {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(value)
+}
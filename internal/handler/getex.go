@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type GetExHandler struct {
+}
+
+func NewGetExHandler() Handler {
+	return &GetExHandler{}
+}
+
+// Handle implements GETEX key [EX seconds | PX milliseconds | EXAT
+// unix-time-seconds | PXAT unix-time-milliseconds | PERSIST], which gets a
+// string and changes its time to live.
+func (h *GetExHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(GETEX)
+	}
+
+	var persist, expires bool
+	var expireAt time.Time
+	switch len(args) {
+	case 1:
+	case 2:
+		if !strings.EqualFold(argString(args[1]), "PERSIST") {
+			return newSyntaxError()
+		}
+		persist = true
+	case 3:
+		opt, ok := expireOptions[strings.ToUpper(argString(args[1]))]
+		if !ok {
+			return newSyntaxError()
+		}
+		var errReply resp.Expression
+		expireAt, errReply = argExpireTime(GETEX, opt, args[2])
+		if errReply != nil {
+			return errReply
+		}
+		expires = true
+	default:
+		return newSyntaxError()
+	}
+
+	key := argString(args[0])
+	var value string
+	var found bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		var err error
+		value, found, err = tx.GetString(key)
+		if found && (persist || expires) {
+			tx.ExpireAt(key, expireAt)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !found {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(value)
+}
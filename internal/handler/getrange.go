@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type GetRangeHandler struct {
+}
+
+func NewGetRangeHandler() Handler {
+	return &GetRangeHandler{}
+}
+
+// Handle implements GETRANGE key start end, where both offsets are inclusive
+// and negative ones count from the end of the string.
+func (h *GetRangeHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(GETRANGE)
+	}
+
+	start, startOK := argInt(args[1])
+	end, endOK := argInt(args[2])
+	if !startOK || !endOK {
+		return newNotIntegerError()
+	}
+
+	var value string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		var err error
+		value, _, err = tx.GetString(argString(args[0]))
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+
+	if start < 0 && end < 0 && start > end {
+		return resp.NewBulkStringExpression("")
+	}
+	length := int64(len(value))
+	if start < 0 {
+		start = max(length+start, 0)
+	}
+	if end < 0 {
+		end = max(length+end, 0)
+	}
+	end = min(end, length-1)
+	if start > end {
+		return resp.NewBulkStringExpression("")
+	}
+	return resp.NewBulkStringExpression(value[start : end+1])
+}
@@ -0,0 +1,35 @@
+package handler
+
+import "testing"
+
+func TestGetRange(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"offsets": {
+			{`SET k "This is a string"`, "+OK\r\n"},
+			{"GETRANGE k 0 3", "$4\r\nThis\r\n"},
+			{"GETRANGE k -3 -1", "$3\r\ning\r\n"},
+			{"GETRANGE k 0 -1", "$16\r\nThis is a string\r\n"},
+			{"GETRANGE k 10 100", "$6\r\nstring\r\n"},
+			{"GETRANGE k 5 3", "$0\r\n\r\n"},
+			{"GETRANGE k -1 -3", "$0\r\n\r\n"},
+			{"GETRANGE k -100 -50", "$1\r\nT\r\n"},
+			{"GETRANGE k 16 20", "$0\r\n\r\n"},
+		},
+		"counts bytes": {
+			{`SET k "caf\xc3\xa9"`, "+OK\r\n"},
+			{"GETRANGE k -2 -1", "$2\r\n\xc3\xa9\r\n"},
+			{"GETRANGE k 3 3", "$1\r\n\xc3\r\n"},
+		},
+		"missing key": {
+			{"GETRANGE k 0 -1", "$0\r\n\r\n"},
+		},
+		"wrong type": {
+			{"RPUSH k a", ":1\r\n"},
+			{"GETRANGE k 0 -1", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+		"invalid offsets": {
+			{"GETRANGE k a 1", "-ERR value is not an integer or out of range\r\n"},
+			{"GETRANGE k 0", "-ERR wrong number of arguments for 'getrange' command\r\n"},
+		},
+	})
+}
@@ -1,23 +1,126 @@
 package handler
 
 import (
-	"github.com/trinhdaiphuc/go-memcache/gomap"
-	"github.com/trinhdaiphuc/go-memcache/hashmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
 	"github.com/trinhdaiphuc/go-memcache/resp"
 )
 
 const (
-	PING    = "PING"
-	GET     = "GET"
-	SET     = "SET"
-	EXPIRED = "EXPIRE"
+	PING         = "PING"
+	GET          = "GET"
+	SET          = "SET"
+	GETEX        = "GETEX"
+	GETDEL       = "GETDEL"
+	SETNX        = "SETNX"
+	SETEX        = "SETEX"
+	PSETEX       = "PSETEX"
+	INCR         = "INCR"
+	DECR         = "DECR"
+	INCRBY       = "INCRBY"
+	DECRBY       = "DECRBY"
+	INCRBYFLOAT  = "INCRBYFLOAT"
+	APPEND       = "APPEND"
+	STRLEN       = "STRLEN"
+	GETRANGE     = "GETRANGE"
+	SETRANGE     = "SETRANGE"
+	MGET         = "MGET"
+	MSET         = "MSET"
+	MSETNX       = "MSETNX"
+	SETBIT       = "SETBIT"
+	GETBIT       = "GETBIT"
+	BITCOUNT     = "BITCOUNT"
+	BITPOS       = "BITPOS"
+	BITOP        = "BITOP"
+	BITFIELD     = "BITFIELD"
+	PFADD        = "PFADD"
+	PFCOUNT      = "PFCOUNT"
+	PFMERGE      = "PFMERGE"
+	EXPIRED      = "EXPIRE"
+	EXPIREAT     = "EXPIREAT"
+	TTL          = "TTL"
+	PTTL         = "PTTL"
+	PERSIST      = "PERSIST"
+	KEYS         = "KEYS"
+	SCAN         = "SCAN"
+	TYPE         = "TYPE"
+	DEL          = "DEL"
+	EXISTS       = "EXISTS"
+	RENAME       = "RENAME"
+	HSET         = "HSET"
+	HSETNX       = "HSETNX"
+	HGET         = "HGET"
+	HMGET        = "HMGET"
+	HDEL         = "HDEL"
+	HEXISTS      = "HEXISTS"
+	HLEN         = "HLEN"
+	HKEYS        = "HKEYS"
+	HVALS        = "HVALS"
+	HGETALL      = "HGETALL"
+	HSTRLEN      = "HSTRLEN"
+	HRANDFIELD   = "HRANDFIELD"
+	HINCRBY      = "HINCRBY"
+	HINCRBYFLOAT = "HINCRBYFLOAT"
+	SADD         = "SADD"
+	SREM         = "SREM"
+	SISMEMBER    = "SISMEMBER"
+	SMEMBERS     = "SMEMBERS"
+	SCARD        = "SCARD"
+	SPOP         = "SPOP"
+	SRANDMEMBER  = "SRANDMEMBER"
+	SUNION       = "SUNION"
+	SINTER       = "SINTER"
+	SDIFF        = "SDIFF"
+	SUNIONSTORE  = "SUNIONSTORE"
+	SINTERSTORE  = "SINTERSTORE"
+	SDIFFSTORE   = "SDIFFSTORE"
+	ZADD         = "ZADD"
+	ZSCORE       = "ZSCORE"
+	ZRANK        = "ZRANK"
+	ZRANGE       = "ZRANGE"
+	ZREM         = "ZREM"
+	ZINCRBY      = "ZINCRBY"
+	ZCARD        = "ZCARD"
+	ZCOUNT       = "ZCOUNT"
+	ZPOPMIN      = "ZPOPMIN"
+	ZPOPMAX      = "ZPOPMAX"
+	LPUSH        = "LPUSH"
+	RPUSH        = "RPUSH"
+	LPOP         = "LPOP"
+	RPOP         = "RPOP"
+	LLEN         = "LLEN"
+	LRANGE       = "LRANGE"
+	LINDEX       = "LINDEX"
+	LSET         = "LSET"
+	LINSERT      = "LINSERT"
+	LREM         = "LREM"
+	LTRIM        = "LTRIM"
+	LMOVE        = "LMOVE"
+	BLPOP        = "BLPOP"
+	BRPOP        = "BRPOP"
+	BLMOVE       = "BLMOVE"
 )
 
 type Func func([]resp.Expression) resp.Expression
 
+// IsBlocking reports whether cmd may block the client that sent it until
+// another client pushes a value.
+func IsBlocking(cmd string) bool {
+	switch cmd {
+	case BLPOP, BRPOP, BLMOVE:
+		return true
+	}
+	return false
+}
+
 type Context struct {
-	Map gomap.Map[string, string]
-	Has hashmap.HashMap[string, string]
+	// Keyspace holds the values of every type. A key holds a single value.
+	Keyspace keyspace.Keyspace
+
+	// Blocked queues the clients blocked on list keys. It is shared by every
+	// connection.
+	Blocked *BlockedClients
+	// Done is closed once the client that sent the command disconnects.
+	Done <-chan struct{}
 }
 
 type Map map[string]Handler
@@ -26,18 +129,113 @@ type Handler interface {
 	Handle(ctx Context, args []resp.Expression) resp.Expression
 }
 
-func NewContext(m gomap.Map[string, string], h hashmap.HashMap[string, string]) Context {
+func NewContext(ks keyspace.Keyspace) Context {
 	return Context{
-		Map: m,
-		Has: h,
+		Keyspace: ks,
+		Blocked:  NewBlockedClients(),
 	}
 }
 
+// WithDone returns a copy of c for the commands of a client whose
+// disconnection closes done.
+func (c Context) WithDone(done <-chan struct{}) Context {
+	c.Done = done
+	return c
+}
+
 func NewMap() Map {
 	return Map{
-		PING:    NewPingHandler(),
-		GET:     NewGetHandler(),
-		SET:     NewSetHandler(),
-		EXPIRED: NewExpiredHandler(),
+		PING:         NewPingHandler(),
+		GET:          NewGetHandler(),
+		SET:          NewSetHandler(),
+		GETEX:        NewGetExHandler(),
+		GETDEL:       NewGetDelHandler(),
+		SETNX:        NewSetNXHandler(),
+		SETEX:        NewSetExHandler(),
+		PSETEX:       NewPSetExHandler(),
+		INCR:         NewIncrHandler(),
+		DECR:         NewDecrHandler(),
+		INCRBY:       NewIncrByHandler(),
+		DECRBY:       NewDecrByHandler(),
+		INCRBYFLOAT:  NewIncrByFloatHandler(),
+		APPEND:       NewAppendHandler(),
+		STRLEN:       NewStrLenHandler(),
+		GETRANGE:     NewGetRangeHandler(),
+		SETRANGE:     NewSetRangeHandler(),
+		MGET:         NewMGetHandler(),
+		MSET:         NewMSetHandler(),
+		MSETNX:       NewMSetNXHandler(),
+		SETBIT:       NewSetBitHandler(),
+		GETBIT:       NewGetBitHandler(),
+		BITCOUNT:     NewBitCountHandler(),
+		BITPOS:       NewBitPosHandler(),
+		BITOP:        NewBitOpHandler(),
+		BITFIELD:     NewBitFieldHandler(),
+		PFADD:        NewPFAddHandler(),
+		PFCOUNT:      NewPFCountHandler(),
+		PFMERGE:      NewPFMergeHandler(),
+		EXPIRED:      NewExpiredHandler(),
+		EXPIREAT:     NewExpireAtHandler(),
+		TTL:          NewTTLHandler(),
+		PTTL:         NewPTTLHandler(),
+		PERSIST:      NewPersistHandler(),
+		KEYS:         NewKeysHandler(),
+		SCAN:         NewScanHandler(),
+		TYPE:         NewTypeHandler(),
+		DEL:          NewDelHandler(),
+		EXISTS:       NewExistsHandler(),
+		RENAME:       NewRenameHandler(),
+		HSET:         NewHSetHandler(),
+		HSETNX:       NewHSetNXHandler(),
+		HGET:         NewHGetHandler(),
+		HMGET:        NewHMGetHandler(),
+		HDEL:         NewHDelHandler(),
+		HEXISTS:      NewHExistsHandler(),
+		HLEN:         NewHLenHandler(),
+		HKEYS:        NewHKeysHandler(),
+		HVALS:        NewHValsHandler(),
+		HGETALL:      NewHGetAllHandler(),
+		HSTRLEN:      NewHStrLenHandler(),
+		HRANDFIELD:   NewHRandFieldHandler(),
+		HINCRBY:      NewHIncrByHandler(),
+		HINCRBYFLOAT: NewHIncrByFloatHandler(),
+		SADD:         NewSAddHandler(),
+		SREM:         NewSRemHandler(),
+		SISMEMBER:    NewSIsMemberHandler(),
+		SMEMBERS:     NewSMembersHandler(),
+		SCARD:        NewSCardHandler(),
+		SPOP:         NewSPopHandler(),
+		SRANDMEMBER:  NewSRandMemberHandler(),
+		SUNION:       NewSUnionHandler(),
+		SINTER:       NewSInterHandler(),
+		SDIFF:        NewSDiffHandler(),
+		SUNIONSTORE:  NewSUnionStoreHandler(),
+		SINTERSTORE:  NewSInterStoreHandler(),
+		SDIFFSTORE:   NewSDiffStoreHandler(),
+		ZADD:         NewZAddHandler(),
+		ZSCORE:       NewZScoreHandler(),
+		ZRANK:        NewZRankHandler(),
+		ZRANGE:       NewZRangeHandler(),
+		ZREM:         NewZRemHandler(),
+		ZINCRBY:      NewZIncrByHandler(),
+		ZCARD:        NewZCardHandler(),
+		ZCOUNT:       NewZCountHandler(),
+		ZPOPMIN:      NewZPopMinHandler(),
+		ZPOPMAX:      NewZPopMaxHandler(),
+		LPUSH:        NewLPushHandler(),
+		RPUSH:        NewRPushHandler(),
+		LPOP:         NewLPopHandler(),
+		RPOP:         NewRPopHandler(),
+		LLEN:         NewLLenHandler(),
+		LRANGE:       NewLRangeHandler(),
+		LINDEX:       NewLIndexHandler(),
+		LSET:         NewLSetHandler(),
+		LINSERT:      NewLInsertHandler(),
+		LREM:         NewLRemHandler(),
+		LTRIM:        NewLTrimHandler(),
+		LMOVE:        NewLMoveHandler(),
+		BLPOP:        NewBLPopHandler(),
+		BRPOP:        NewBRPopHandler(),
+		BLMOVE:       NewBLMoveHandler(),
 	}
 }
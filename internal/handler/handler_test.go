@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// step is a command sent to the handlers together with its expected reply,
+// serialized as RESP.
+type step struct {
+	cmd  string
+	want string
+}
+
+func newTestContext() Context {
+	return NewContext(keyspace.NewKeyspace())
+}
+
+// call runs the command made of args against ctx and returns its serialized
+// reply.
+func call(t *testing.T, ctx Context, args ...string) string {
+	t.Helper()
+
+	h, ok := NewMap()[strings.ToUpper(args[0])]
+	if !ok {
+		t.Fatalf("unknown command %q", args[0])
+	}
+
+	exprs := make([]resp.Expression, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		exprs = append(exprs, resp.NewBulkStringExpression(arg))
+	}
+	return h.Handle(ctx, exprs).Serialize()
+}
+
+// runSteps runs every test case on a fresh keyspace, sending the commands of
+// its steps in order.
+func runSteps(t *testing.T, tests map[string][]step) {
+	t.Helper()
+
+	for name, steps := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := newTestContext()
+			for _, s := range steps {
+				got := call(t, ctx, splitArgs(t, s.cmd)...)
+				assert.Equalf(t, s.want, got, "%s = %q; want %q", s.cmd, got, s.want)
+			}
+		})
+	}
+}
+
+// bulks returns the serialized array of the bulk strings values.
+func bulks(values ...string) string {
+	var b strings.Builder
+	b.WriteString("*" + strconv.Itoa(len(values)) + "\r\n")
+	for _, value := range values {
+		b.WriteString("$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n")
+	}
+	return b.String()
+}
+
+// splitArgs splits cmd into arguments separated by spaces. An argument in
+// double quotes is unquoted as a Go string literal, so it may hold spaces or
+// be empty.
+func splitArgs(t *testing.T, cmd string) []string {
+	t.Helper()
+
+	var args []string
+	for cmd = strings.TrimLeft(cmd, " "); cmd != ""; cmd = strings.TrimLeft(cmd, " ") {
+		if cmd[0] != '"' {
+			arg, rest, _ := strings.Cut(cmd, " ")
+			args, cmd = append(args, arg), rest
+			continue
+		}
+
+		end := 1
+		for end < len(cmd) && cmd[end] != '"' {
+			if cmd[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		arg, err := strconv.Unquote(cmd[:min(end+1, len(cmd))])
+		if err != nil {
+			t.Fatalf("invalid quoted argument in %q: %v", cmd, err)
+		}
+		args, cmd = append(args, arg), cmd[min(end+1, len(cmd)):]
+	}
+	return args
+}
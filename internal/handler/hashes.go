@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/hashmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+)
+
+type hash = hashmap.Hash[string, string]
+
+// getHash returns a copy of the fields of the hash at key, or nil if the key
+// does not exist. Commands reading a few fields use viewHash instead, which
+// does not cost the size of the hash.
+func getHash(ctx Context, key string) (map[string]string, error) {
+	var fields map[string]string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		h, ok, err := tx.GetHash(key)
+		if ok {
+			fields = h.Map()
+		}
+		return err
+	})
+	return fields, err
+}
+
+// viewHash runs fn on the hash at key, unless the key does not exist. fn must
+// not modify the hash.
+func viewHash(ctx Context, key string, fn func(h *hash)) error {
+	return ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		h, ok, err := tx.GetHash(key)
+		if ok {
+			fn(h)
+		}
+		return err
+	})
+}
+
+// updateHash runs fn on the hash at key, creating it if needed.
+func updateHash(ctx Context, key string, fn func(h *hash) error) error {
+	return ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		h, err := tx.GetOrCreateHash(key)
+		if err != nil {
+			return err
+		}
+		return fn(h)
+	})
+}
@@ -0,0 +1,98 @@
+package handler
+
+import "testing"
+
+func TestHashFieldReads(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"existing fields": {
+			{"HSET h name user1 email user1@gmail.com", ":2\r\n"},
+			{"HGET h name", "$5\r\nuser1\r\n"},
+			{"HEXISTS h email", ":1\r\n"},
+			{"HSTRLEN h email", ":15\r\n"},
+			{"HMGET h name missing email", "*3\r\n$5\r\nuser1\r\n$-1\r\n$15\r\nuser1@gmail.com\r\n"},
+		},
+		"missing fields": {
+			{"HSET h name user1", ":1\r\n"},
+			{"HGET h missing", "$-1\r\n"},
+			{"HEXISTS h missing", ":0\r\n"},
+			{"HSTRLEN h missing", ":0\r\n"},
+		},
+		"missing key": {
+			{"HGET h name", "$-1\r\n"},
+			{"HEXISTS h name", ":0\r\n"},
+			{"HSTRLEN h name", ":0\r\n"},
+			{"HMGET h a b", "*2\r\n$-1\r\n$-1\r\n"},
+			{"EXISTS h", ":0\r\n"},
+		},
+		"wrong type": {
+			{"SET h v", "+OK\r\n"},
+			{"HGET h name", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"HEXISTS h name", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"HSTRLEN h name", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"HMGET h name", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+		"wrong number of arguments": {
+			{"HGET h", "-ERR wrong number of arguments for 'hget' command\r\n"},
+			{"HEXISTS h", "-ERR wrong number of arguments for 'hexists' command\r\n"},
+			{"HSTRLEN h a b", "-ERR wrong number of arguments for 'hstrlen' command\r\n"},
+			{"HMGET h", "-ERR wrong number of arguments for 'hmget' command\r\n"},
+		},
+	})
+}
+
+func TestHashIncrements(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"hincrby": {
+			{"HINCRBY h n 5", ":5\r\n"},
+			{"HINCRBY h n -7", ":-2\r\n"},
+			{"HGET h n", "$2\r\n-2\r\n"},
+		},
+		"hincrby overflow": {
+			{"HSET h n 9223372036854775807", ":1\r\n"},
+			{"HINCRBY h n 1", "-ERR increment or decrement would overflow\r\n"},
+			{"HSET h m -9223372036854775808", ":1\r\n"},
+			{"HINCRBY h m -1", "-ERR increment or decrement would overflow\r\n"},
+			{"HGET h n", "$19\r\n9223372036854775807\r\n"},
+		},
+		"hincrby non-integer field": {
+			{"HSET h a abc b +5 c 007 d 1.5", ":4\r\n"},
+			{"HINCRBY h a 1", "-ERR hash value is not an integer\r\n"},
+			{"HINCRBY h b 1", "-ERR hash value is not an integer\r\n"},
+			{"HINCRBY h c 1", "-ERR hash value is not an integer\r\n"},
+			{"HINCRBY h d 1", "-ERR hash value is not an integer\r\n"},
+		},
+		"hincrby non-integer increment": {
+			{"HINCRBY h n +5", "-ERR value is not an integer or out of range\r\n"},
+			{"HINCRBY h n 05", "-ERR value is not an integer or out of range\r\n"},
+			{"HINCRBY h n 9223372036854775808", "-ERR value is not an integer or out of range\r\n"},
+			{"EXISTS h", ":0\r\n"},
+		},
+		"hincrbyfloat": {
+			{"HINCRBYFLOAT h n 10.5", "$4\r\n10.5\r\n"},
+			{"HINCRBYFLOAT h n 0.1", "$4\r\n10.6\r\n"},
+			{"HSET h m 5.0e3", ":1\r\n"},
+			{"HINCRBYFLOAT h m 200", "$4\r\n5200\r\n"},
+		},
+		"hincrbyfloat nan or inf": {
+			{"HSET h n 1.7976931348623157e308", ":1\r\n"},
+			{"HINCRBYFLOAT h n 1.7976931348623157e308", "-ERR increment would produce NaN or Infinity\r\n"},
+			{"HINCRBYFLOAT h n inf", "-ERR value is not a valid float\r\n"},
+			{"HINCRBYFLOAT h n nan", "-ERR value is not a valid float\r\n"},
+			{"HGET h n", "$22\r\n1.7976931348623157e308\r\n"},
+		},
+		"hincrbyfloat non-float field": {
+			{"HSET h a abc b inf", ":2\r\n"},
+			{"HINCRBYFLOAT h a 1", "-ERR hash value is not a float\r\n"},
+			{"HINCRBYFLOAT h b 1", "-ERR hash value is not a float\r\n"},
+		},
+		"wrong type": {
+			{"SET h v", "+OK\r\n"},
+			{"HINCRBY h n 1", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"HINCRBYFLOAT h n 1", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+		"wrong number of arguments": {
+			{"HINCRBY h n", "-ERR wrong number of arguments for 'hincrby' command\r\n"},
+			{"HINCRBYFLOAT h n 1 2", "-ERR wrong number of arguments for 'hincrbyfloat' command\r\n"},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type HDelHandler struct {
+}
+
+func NewHDelHandler() Handler {
+	return &HDelHandler{}
+}
+
+func (h *HDelHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 {
+		return newWrongNumberOfArgsError(HDEL)
+	}
+
+	deleted := 0
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		h, ok, err := tx.GetHash(argString(args[0]))
+		if ok {
+			deleted = h.Delete(argStrings(args[1:])...)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(deleted)
+}
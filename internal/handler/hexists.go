@@ -0,0 +1,28 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HExistsHandler struct {
+}
+
+func NewHExistsHandler() Handler {
+	return &HExistsHandler{}
+}
+
+func (h *HExistsHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(HEXISTS)
+	}
+
+	var ok bool
+	err := viewHash(ctx, argString(args[0]), func(h *hash) {
+		_, ok = h.Get(argString(args[1]))
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !ok {
+		return resp.NewIntegerExpression(0)
+	}
+	return resp.NewIntegerExpression(1)
+}
@@ -0,0 +1,29 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HGetHandler struct {
+}
+
+func NewHGetHandler() Handler {
+	return &HGetHandler{}
+}
+
+func (h *HGetHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(HGET)
+	}
+
+	var value string
+	var ok bool
+	err := viewHash(ctx, argString(args[0]), func(h *hash) {
+		value, ok = h.Get(argString(args[1]))
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !ok {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(value)
+}
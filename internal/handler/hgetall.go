@@ -0,0 +1,26 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HGetAllHandler struct {
+}
+
+func NewHGetAllHandler() Handler {
+	return &HGetAllHandler{}
+}
+
+func (h *HGetAllHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(HGETALL)
+	}
+
+	fields, err := getHash(ctx, argString(args[0]))
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	values := make([]string, 0, 2*len(fields))
+	for field, value := range fields {
+		values = append(values, field, value)
+	}
+	return resp.NewBulkStringArrayExpression(values)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/hashmap"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type HIncrByHandler struct {
+}
+
+func NewHIncrByHandler() Handler {
+	return &HIncrByHandler{}
+}
+
+func (h *HIncrByHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(HINCRBY)
+	}
+
+	delta, ok := argInt(args[2])
+	if !ok {
+		return newNotIntegerError()
+	}
+
+	var value int64
+	err := updateHash(ctx, argString(args[0]), func(h *hash) error {
+		var err error
+		value, err = hashmap.HashIncrBy(h, argString(args[1]), delta)
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(int(value))
+}
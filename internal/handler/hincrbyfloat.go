@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/trinhdaiphuc/go-memcache/hashmap"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type HIncrByFloatHandler struct {
+}
+
+func NewHIncrByFloatHandler() Handler {
+	return &HIncrByFloatHandler{}
+}
+
+func (h *HIncrByFloatHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(HINCRBYFLOAT)
+	}
+
+	delta, ok := argFloat(args[2])
+	if !ok || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return newNotFloatError()
+	}
+
+	var value float64
+	err := updateHash(ctx, argString(args[0]), func(h *hash) error {
+		var err error
+		value, err = hashmap.HashIncrByFloat(h, argString(args[1]), delta)
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewBulkStringExpression(strconv.FormatFloat(value, 'f', -1, 64))
+}
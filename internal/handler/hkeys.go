@@ -0,0 +1,26 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HKeysHandler struct {
+}
+
+func NewHKeysHandler() Handler {
+	return &HKeysHandler{}
+}
+
+func (h *HKeysHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(HKEYS)
+	}
+
+	fields, err := getHash(ctx, argString(args[0]))
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	keys := make([]string, 0, len(fields))
+	for field := range fields {
+		keys = append(keys, field)
+	}
+	return resp.NewBulkStringArrayExpression(keys)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type HLenHandler struct {
+}
+
+func NewHLenHandler() Handler {
+	return &HLenHandler{}
+}
+
+func (h *HLenHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(HLEN)
+	}
+
+	length := 0
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		h, ok, err := tx.GetHash(argString(args[0]))
+		if ok {
+			length = h.Len()
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(length)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HMGetHandler struct {
+}
+
+func NewHMGetHandler() Handler {
+	return &HMGetHandler{}
+}
+
+func (h *HMGetHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 {
+		return newWrongNumberOfArgsError(HMGET)
+	}
+
+	values := make([]resp.Expression, len(args)-1)
+	for i := range values {
+		values[i] = resp.NewNullBulkStringExpression()
+	}
+	err := viewHash(ctx, argString(args[0]), func(h *hash) {
+		for i, arg := range args[1:] {
+			if value, ok := h.Get(argString(arg)); ok {
+				values[i] = resp.NewBulkStringExpression(value)
+			}
+		}
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewArrayExpression(values...)
+}
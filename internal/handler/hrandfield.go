@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type HRandFieldHandler struct {
+}
+
+func NewHRandFieldHandler() Handler {
+	return &HRandFieldHandler{}
+}
+
+// Handle implements HRANDFIELD key [count [WITHVALUES]]. A positive count
+// returns distinct fields, a negative one may return the same field several
+// times.
+func (h *HRandFieldHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 || len(args) > 3 {
+		return newWrongNumberOfArgsError(HRANDFIELD)
+	}
+
+	fields, err := getHash(ctx, argString(args[0]))
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	keys := make([]string, 0, len(fields))
+	for field := range fields {
+		keys = append(keys, field)
+	}
+
+	if len(args) == 1 {
+		if len(keys) == 0 {
+			return resp.NewNullBulkStringExpression()
+		}
+		return resp.NewBulkStringExpression(keys[rand.IntN(len(keys))])
+	}
+
+	count, errReply := argRandomCount(args[1])
+	if errReply != nil {
+		return errReply
+	}
+	withValues := false
+	if len(args) == 3 {
+		if !strings.EqualFold(argString(args[2]), "WITHVALUES") {
+			return newSyntaxError()
+		}
+		withValues = true
+	}
+
+	var picked []string
+	if count >= 0 {
+		rand.Shuffle(len(keys), func(i, j int) {
+			keys[i], keys[j] = keys[j], keys[i]
+		})
+		picked = keys[:min(int(count), len(keys))]
+	} else if len(keys) > 0 {
+		picked = make([]string, 0, -count)
+		for i := int64(0); i < -count; i++ {
+			picked = append(picked, keys[rand.IntN(len(keys))])
+		}
+	}
+
+	values := make([]string, 0, 2*len(picked))
+	for _, field := range picked {
+		values = append(values, field)
+		if withValues {
+			values = append(values, fields[field])
+		}
+	}
+	return resp.NewBulkStringArrayExpression(values)
+}
@@ -0,0 +1,27 @@
+package handler
+
+import "testing"
+
+func TestHRandField(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"missing key": {
+			{"HRANDFIELD h", "$-1\r\n"},
+			{"HRANDFIELD h 3", "*0\r\n"},
+			{"HRANDFIELD h -3", "*0\r\n"},
+		},
+		"single field": {
+			{"HSET h f v", ":1\r\n"},
+			{"HRANDFIELD h", "$1\r\nf\r\n"},
+			{"HRANDFIELD h 5 WITHVALUES", "*2\r\n$1\r\nf\r\n$1\r\nv\r\n"},
+			{"HRANDFIELD h -2", "*2\r\n$1\r\nf\r\n$1\r\nf\r\n"},
+			{"HRANDFIELD h 0", "*0\r\n"},
+		},
+		"invalid count": {
+			{"HSET h f v", ":1\r\n"},
+			{"HRANDFIELD h x", "-ERR value is not an integer or out of range\r\n"},
+			{"HRANDFIELD h 1 WITHSCORES", "-ERR syntax error\r\n"},
+			{"HRANDFIELD h -9223372036854775808", "-ERR value is out of range, must be between -16777216 and 9223372036854775807\r\n"},
+			{"HRANDFIELD h -1000000000000", "-ERR value is out of range, must be between -16777216 and 9223372036854775807\r\n"},
+		},
+	})
+}
@@ -0,0 +1,30 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HSetHandler struct {
+}
+
+func NewHSetHandler() Handler {
+	return &HSetHandler{}
+}
+
+func (h *HSetHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 3 || len(args)%2 != 1 {
+		return newWrongNumberOfArgsError(HSET)
+	}
+
+	added := 0
+	err := updateHash(ctx, argString(args[0]), func(h *hash) error {
+		for i := 1; i < len(args); i += 2 {
+			if h.Set(argString(args[i]), argString(args[i+1])) {
+				added++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(added)
+}
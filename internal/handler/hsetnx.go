@@ -0,0 +1,31 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HSetNXHandler struct {
+}
+
+func NewHSetNXHandler() Handler {
+	return &HSetNXHandler{}
+}
+
+func (h *HSetNXHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(HSETNX)
+	}
+
+	set := false
+	err := updateHash(ctx, argString(args[0]), func(h *hash) error {
+		if _, ok := h.Get(argString(args[1])); !ok {
+			set = h.Set(argString(args[1]), argString(args[2]))
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !set {
+		return resp.NewIntegerExpression(0)
+	}
+	return resp.NewIntegerExpression(1)
+}
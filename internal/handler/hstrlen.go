@@ -0,0 +1,25 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HStrLenHandler struct {
+}
+
+func NewHStrLenHandler() Handler {
+	return &HStrLenHandler{}
+}
+
+func (h *HStrLenHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(HSTRLEN)
+	}
+
+	var value string
+	err := viewHash(ctx, argString(args[0]), func(h *hash) {
+		value, _ = h.Get(argString(args[1]))
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(len(value))
+}
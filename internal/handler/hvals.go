@@ -0,0 +1,26 @@
+package handler
+
+import "github.com/trinhdaiphuc/go-memcache/resp"
+
+type HValsHandler struct {
+}
+
+func NewHValsHandler() Handler {
+	return &HValsHandler{}
+}
+
+func (h *HValsHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(HVALS)
+	}
+
+	fields, err := getHash(ctx, argString(args[0]))
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	values := make([]string, 0, len(fields))
+	for _, value := range fields {
+		values = append(values, value)
+	}
+	return resp.NewBulkStringArrayExpression(values)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/trinhdaiphuc/go-memcache/hyperloglog"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// getHyperLogLog returns the HyperLogLog stored in the string at key, and
+// whether the key exists. Like Redis, HyperLogLogs are plain strings, so that
+// they can be copied with GET and SET.
+func getHyperLogLog(tx keyspace.Tx, key string) (*hyperloglog.HyperLogLog, bool, error) {
+	value, ok, err := tx.GetString(key)
+	if !ok {
+		return nil, false, err
+	}
+
+	h, err := hyperloglog.Parse([]byte(value))
+	return h, err == nil, err
+}
+
+// newHyperLogLogErrorExpression replies with err, reporting a string that is
+// not a HyperLogLog as a key of the wrong type and a HyperLogLog that cannot
+// be decoded as an invalid object, as Redis does.
+func newHyperLogLogErrorExpression(err error) resp.Expression {
+	switch {
+	case errors.Is(err, hyperloglog.ErrInvalid):
+		return resp.NewErrorExpression("WRONGTYPE Key is not a valid HyperLogLog string value.")
+	case errors.Is(err, hyperloglog.ErrCorrupt):
+		return resp.NewErrorExpression("INVALIDOBJ Corrupted HLL object detected")
+	}
+	return newErrorExpression(err)
+}
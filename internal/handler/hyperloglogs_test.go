@@ -0,0 +1,76 @@
+package handler
+
+import "testing"
+
+func TestHyperLogLogs(t *testing.T) {
+	const (
+		notHyperLogLog = "-WRONGTYPE Key is not a valid HyperLogLog string value.\r\n"
+		corrupted      = "-INVALIDOBJ Corrupted HLL object detected\r\n"
+	)
+
+	runSteps(t, map[string][]step{
+		"PFADD replies whether the estimate may have changed": {
+			{"PFADD k a b c", ":1\r\n"},
+			{"PFADD k a", ":0\r\n"},
+			{"PFADD k", ":0\r\n"},
+			{"PFADD new", ":1\r\n"},
+			{"EXISTS new", ":1\r\n"},
+			{"PFCOUNT k", ":3\r\n"},
+			{"PFCOUNT new", ":0\r\n"},
+		},
+		"PFADD keeps the time to live": {
+			{"PFADD k a", ":1\r\n"},
+			{"EXPIRE k 100", ":1\r\n"},
+			{"PFADD k b", ":1\r\n"},
+			{"TTL k", ":100\r\n"},
+		},
+		"PFCOUNT of several keys counts their union": {
+			{"PFADD a x y z", ":1\r\n"},
+			{"PFADD b z w", ":1\r\n"},
+			{"PFCOUNT a b", ":4\r\n"},
+			{"PFCOUNT a b missing", ":4\r\n"},
+			{"PFCOUNT a", ":3\r\n"},
+			{"PFCOUNT missing", ":0\r\n"},
+			{"EXISTS missing", ":0\r\n"},
+		},
+		"PFMERGE": {
+			{"PFADD d 1 2", ":1\r\n"},
+			{"PFADD s 2 3 4", ":1\r\n"},
+			{"PFMERGE d s missing", "+OK\r\n"},
+			{"PFCOUNT d", ":4\r\n"},
+			{"PFCOUNT s", ":3\r\n"},
+			{"PFMERGE new s", "+OK\r\n"},
+			{"PFCOUNT new", ":3\r\n"},
+			{"PFMERGE empty", "+OK\r\n"},
+			{"PFCOUNT empty", ":0\r\n"},
+			{"EXISTS empty", ":1\r\n"},
+		},
+		"string that is not a HyperLogLog": {
+			{"SET s v", "+OK\r\n"},
+			{"PFADD s a", notHyperLogLog},
+			{"PFCOUNT s", notHyperLogLog},
+			{"PFADD k a", ":1\r\n"},
+			{"PFCOUNT k s", notHyperLogLog},
+			{"PFMERGE s k", notHyperLogLog},
+			{"PFMERGE k s", notHyperLogLog},
+			{"GET s", "$1\r\nv\r\n"},
+		},
+		"corrupted HyperLogLog": {
+			{`SET c "HYLL\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80\x00"`, "+OK\r\n"},
+			{"PFADD c a", corrupted},
+			{"PFCOUNT c", corrupted},
+			{"PFMERGE d c", corrupted},
+			{"EXISTS d", ":0\r\n"},
+		},
+		"other types": {
+			{"RPUSH l a", ":1\r\n"},
+			{"PFADD l a", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"PFCOUNT l", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+		"wrong number of arguments": {
+			{"PFADD", "-ERR wrong number of arguments for 'pfadd' command\r\n"},
+			{"PFCOUNT", "-ERR wrong number of arguments for 'pfcount' command\r\n"},
+			{"PFMERGE", "-ERR wrong number of arguments for 'pfmerge' command\r\n"},
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+var (
+	errNotInteger = errors.New("value is not an integer or out of range")
+	errOverflow   = errors.New("increment or decrement would overflow")
+)
+
+// IncrByHandler implements INCR, DECR, INCRBY and DECRBY, which add to the
+// integer stored in a string, read as 0 if the key does not exist, and keep
+// its time to live.
+type IncrByHandler struct {
+	cmd string
+	// sign is -1 for the commands that decrement.
+	sign int64
+	// withDelta is set for the commands given the amount to add.
+	withDelta bool
+}
+
+func NewIncrHandler() Handler {
+	return &IncrByHandler{cmd: INCR, sign: 1}
+}
+
+func NewDecrHandler() Handler {
+	return &IncrByHandler{cmd: DECR, sign: -1}
+}
+
+func NewIncrByHandler() Handler {
+	return &IncrByHandler{cmd: INCRBY, sign: 1, withDelta: true}
+}
+
+func NewDecrByHandler() Handler {
+	return &IncrByHandler{cmd: DECRBY, sign: -1, withDelta: true}
+}
+
+func (h *IncrByHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if (h.withDelta && len(args) != 2) || (!h.withDelta && len(args) != 1) {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	delta := int64(1)
+	if h.withDelta {
+		var ok bool
+		if delta, ok = argInt(args[1]); !ok {
+			return newNotIntegerError()
+		}
+	}
+	if h.sign < 0 {
+		if delta == math.MinInt64 {
+			return resp.NewErrorExpression("ERR decrement would overflow")
+		}
+		delta = -delta
+	}
+
+	key := argString(args[0])
+	var result int64
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, ok, err := tx.GetString(key)
+		if err != nil {
+			return err
+		}
+
+		var current int64
+		if ok {
+			if current, ok = parseInt(value); !ok {
+				return errNotInteger
+			}
+		}
+		if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+			return errOverflow
+		}
+
+		result = current + delta
+		setStringKeepTTL(tx, key, strconv.FormatInt(result, 10))
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(int(result))
+}
@@ -0,0 +1,88 @@
+package handler
+
+import "testing"
+
+func TestIncrBy(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"counts from zero": {
+			{"INCR k", ":1\r\n"},
+			{"INCRBY k 10", ":11\r\n"},
+			{"DECR k", ":10\r\n"},
+			{"DECRBY k 15", ":-5\r\n"},
+			{"GET k", "$2\r\n-5\r\n"},
+		},
+		"keeps the time to live": {
+			{"SET k 1 EX 100", "+OK\r\n"},
+			{"INCR k", ":2\r\n"},
+			{"TTL k", ":100\r\n"},
+		},
+		"not an integer": {
+			{"SET k abc", "+OK\r\n"},
+			{"INCR k", "-ERR value is not an integer or out of range\r\n"},
+			{"SET k 1.5", "+OK\r\n"},
+			{"INCR k", "-ERR value is not an integer or out of range\r\n"},
+			{"SET k +5", "+OK\r\n"},
+			{"INCR k", "-ERR value is not an integer or out of range\r\n"},
+			{"SET k 007", "+OK\r\n"},
+			{"INCR k", "-ERR value is not an integer or out of range\r\n"},
+			{"SET k -0", "+OK\r\n"},
+			{"INCR k", "-ERR value is not an integer or out of range\r\n"},
+			{`SET k " 1"`, "+OK\r\n"},
+			{"INCR k", "-ERR value is not an integer or out of range\r\n"},
+			{`SET k ""`, "+OK\r\n"},
+			{"INCR k", "-ERR value is not an integer or out of range\r\n"},
+			{"SET k 9223372036854775808", "+OK\r\n"},
+			{"INCR k", "-ERR value is not an integer or out of range\r\n"},
+			{"SET k 0", "+OK\r\n"},
+			{"INCRBY k +5", "-ERR value is not an integer or out of range\r\n"},
+			{"INCRBY k 05", "-ERR value is not an integer or out of range\r\n"},
+			{"INCRBY k x", "-ERR value is not an integer or out of range\r\n"},
+			{"GET k", "$1\r\n0\r\n"},
+		},
+		"overflow": {
+			{"SET k 9223372036854775807", "+OK\r\n"},
+			{"INCR k", "-ERR increment or decrement would overflow\r\n"},
+			{"SET k -9223372036854775808", "+OK\r\n"},
+			{"DECR k", "-ERR increment or decrement would overflow\r\n"},
+			{"INCRBY k -1", "-ERR increment or decrement would overflow\r\n"},
+			{"SET k 0", "+OK\r\n"},
+			{"DECRBY k -9223372036854775808", "-ERR decrement would overflow\r\n"},
+			{"GET k", "$1\r\n0\r\n"},
+		},
+		"wrong type": {
+			{"RPUSH k 1", ":1\r\n"},
+			{"INCR k", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+		"wrong number of arguments": {
+			{"INCR", "-ERR wrong number of arguments for 'incr' command\r\n"},
+			{"INCRBY k", "-ERR wrong number of arguments for 'incrby' command\r\n"},
+		},
+	})
+}
+
+func TestIncrByFloat(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"adds": {
+			{"SET k 10.50", "+OK\r\n"},
+			{"INCRBYFLOAT k 0.1", "$4\r\n10.6\r\n"},
+			{"INCRBYFLOAT k -5", "$3\r\n5.6\r\n"},
+			{"SET k 5.0e3", "+OK\r\n"},
+			{"INCRBYFLOAT k 2.0e2", "$4\r\n5200\r\n"},
+			{"INCRBYFLOAT new 3", "$1\r\n3\r\n"},
+		},
+		"errors": {
+			{"SET k abc", "+OK\r\n"},
+			{"INCRBYFLOAT k 1", "-ERR value is not a valid float\r\n"},
+			{"SET k 1", "+OK\r\n"},
+			{"INCRBYFLOAT k x", "-ERR value is not a valid float\r\n"},
+			{"INCRBYFLOAT k inf", "-ERR value is not a valid float\r\n"},
+			{"SET k 1.7e308", "+OK\r\n"},
+			{"INCRBYFLOAT k 1.7e308", "-ERR increment would produce NaN or Infinity\r\n"},
+			{"GET k", "$7\r\n1.7e308\r\n"},
+		},
+		"wrong type": {
+			{"SADD k 1", ":1\r\n"},
+			{"INCRBYFLOAT k 1", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+var (
+	errNotFloat = errors.New("value is not a valid float")
+	errNaN      = errors.New("increment would produce NaN or Infinity")
+)
+
+type IncrByFloatHandler struct {
+}
+
+func NewIncrByFloatHandler() Handler {
+	return &IncrByFloatHandler{}
+}
+
+// Handle implements INCRBYFLOAT key increment, which adds to the float stored
+// in a string, read as 0 if the key does not exist, and keeps its time to
+// live.
+func (h *IncrByFloatHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(INCRBYFLOAT)
+	}
+
+	delta, ok := argFloat(args[1])
+	if !ok || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return newNotFloatError()
+	}
+
+	key := argString(args[0])
+	var result string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, ok, err := tx.GetString(key)
+		if err != nil {
+			return err
+		}
+
+		var current float64
+		if ok {
+			current, err = strconv.ParseFloat(value, 64)
+			if err != nil || math.IsNaN(current) || math.IsInf(current, 0) {
+				return errNotFloat
+			}
+		}
+		sum := current + delta
+		if math.IsNaN(sum) || math.IsInf(sum, 0) {
+			return errNaN
+		}
+
+		result = strconv.FormatFloat(sum, 'f', -1, 64)
+		setStringKeepTTL(tx, key, result)
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewBulkStringExpression(result)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type KeysHandler struct {
+}
+
+func NewKeysHandler() Handler {
+	return &KeysHandler{}
+}
+
+// Handle implements KEYS pattern, which lists every key matching a
+// glob-style pattern.
+func (h *KeysHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(KEYS)
+	}
+
+	pattern := argString(args[0])
+	var keys []string
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		for _, key := range tx.Keys() {
+			if keyspace.Match(pattern, key) {
+				keys = append(keys, key)
+			}
+		}
+		return nil
+	})
+	return resp.NewBulkStringArrayExpression(keys)
+}
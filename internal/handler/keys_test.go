@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+func TestExpire(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"missing key": {
+			{"EXPIRE k 10", ":0\r\n"},
+			{"TTL k", ":-2\r\n"},
+			{"PTTL k", ":-2\r\n"},
+		},
+		"any type": {
+			{"RPUSH k a", ":1\r\n"},
+			{"TTL k", ":-1\r\n"},
+			{"EXPIRE k 100", ":1\r\n"},
+			{"TTL k", ":100\r\n"},
+			{"PERSIST k", ":1\r\n"},
+			{"PERSIST k", ":0\r\n"},
+			{"TTL k", ":-1\r\n"},
+		},
+		"non-positive ttl deletes the key": {
+			{"SET k v", "+OK\r\n"},
+			{"EXPIRE k 0", ":1\r\n"},
+			{"EXISTS k", ":0\r\n"},
+			{"SET k v", "+OK\r\n"},
+			{"EXPIRE k -5", ":1\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"EXPIREAT": {
+			{"SET k v", "+OK\r\n"},
+			{"EXPIREAT k 4102444800", ":1\r\n"},
+			{"PERSIST k", ":1\r\n"},
+			{"EXPIREAT k 1", ":1\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"EXPIRE k x", "-ERR value is not an integer or out of range\r\n"},
+			{"EXPIRE k 9223372036854775807", "-ERR invalid expire time in 'expire' command\r\n"},
+			{"EXPIRE k", "-ERR wrong number of arguments for 'expire' command\r\n"},
+		},
+	})
+}
+
+func TestKeys(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"pattern": {
+			{"MSET user:1 a user:2 b order:1 c", "+OK\r\n"},
+			{"KEYS order:*", "*1\r\n$7\r\norder:1\r\n"},
+			{"KEYS user:[3-9]", "*0\r\n"},
+			{"KEYS", "-ERR wrong number of arguments for 'keys' command\r\n"},
+		},
+	})
+}
+
+// scanAll iterates SCAN with opts until the cursor returns to zero and
+// returns every key it replied with.
+func scanAll(t *testing.T, ctx Context, opts ...string) []string {
+	t.Helper()
+
+	var keys []string
+	cursor := "0"
+	for {
+		args := []resp.Expression{resp.NewBulkStringExpression(cursor)}
+		for _, opt := range opts {
+			args = append(args, resp.NewBulkStringExpression(opt))
+		}
+
+		reply, ok := NewScanHandler().Handle(ctx, args).(*resp.ArrayExpression)
+		if !ok || len(reply.Expressions) != 2 {
+			t.Fatalf("SCAN %s replied %v", cursor, reply)
+		}
+		cursor = argString(reply.Expressions[0])
+		for _, key := range reply.Expressions[1].(*resp.ArrayExpression).Expressions {
+			keys = append(keys, argString(key))
+		}
+		if cursor == "0" {
+			return keys
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	ctx := newTestContext()
+	var all, users []string
+	for i := 0; i < 50; i++ {
+		key := "user:" + strconv.Itoa(i)
+		call(t, ctx, "SET", key, "v")
+		all, users = append(all, key), append(users, key)
+
+		key = "queue:" + strconv.Itoa(i)
+		call(t, ctx, "RPUSH", key, "v")
+		all = append(all, key)
+	}
+
+	assert.ElementsMatch(t, all, scanAll(t, ctx))
+	assert.ElementsMatch(t, all, scanAll(t, ctx, "COUNT", "3"))
+	assert.ElementsMatch(t, users, scanAll(t, ctx, "MATCH", "user:*", "COUNT", "7"))
+	assert.ElementsMatch(t, users, scanAll(t, ctx, "TYPE", "STRING"))
+	assert.Empty(t, scanAll(t, ctx, "MATCH", "user:*", "TYPE", "list"))
+
+	runSteps(t, map[string][]step{
+		"empty keyspace": {
+			{"SCAN 0", "*2\r\n$1\r\n0\r\n*0\r\n"},
+		},
+		"invalid arguments": {
+			{"SCAN x", "-ERR invalid cursor\r\n"},
+			{"SCAN -1", "-ERR invalid cursor\r\n"},
+			{"SCAN 0 COUNT 0", "-ERR syntax error\r\n"},
+			{"SCAN 0 COUNT x", "-ERR value is not an integer or out of range\r\n"},
+			{"SCAN 0 MATCH", "-ERR syntax error\r\n"},
+			{"SCAN 0 LIMIT 1", "-ERR syntax error\r\n"},
+			{"SCAN", "-ERR wrong number of arguments for 'scan' command\r\n"},
+		},
+	})
+}
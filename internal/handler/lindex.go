@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type LIndexHandler struct {
+}
+
+func NewLIndexHandler() Handler {
+	return &LIndexHandler{}
+}
+
+func (h *LIndexHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(LINDEX)
+	}
+
+	index, ok := argInt(args[1])
+	if !ok {
+		return newNotIntegerError()
+	}
+
+	var value string
+	var found bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, ok, err := tx.GetList(argString(args[0]))
+		if ok {
+			value, found = l.Index(int(index))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !found {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(value)
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type LInsertHandler struct {
+}
+
+func NewLInsertHandler() Handler {
+	return &LInsertHandler{}
+}
+
+// Handle implements LINSERT key BEFORE|AFTER pivot element. It replies with
+// the length of the list, -1 when pivot is not found and 0 when the list does
+// not exist.
+func (h *LInsertHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 4 {
+		return newWrongNumberOfArgsError(LINSERT)
+	}
+
+	var before bool
+	switch strings.ToUpper(argString(args[1])) {
+	case "BEFORE":
+		before = true
+	case "AFTER":
+	default:
+		return newSyntaxError()
+	}
+
+	var length int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, ok, err := tx.GetList(argString(args[0]))
+		if ok {
+			length = l.Insert(argString(args[2]), argString(args[3]), before)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(length)
+}
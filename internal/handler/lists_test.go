@@ -0,0 +1,216 @@
+package handler
+
+import "testing"
+
+const wrongTypeReply = "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"
+
+func TestPushAndRange(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"pushes to both ends": {
+			{"LPUSH k a b c", ":3\r\n"},
+			{"RPUSH k d", ":4\r\n"},
+			{"LRANGE k 0 -1", bulks("c", "b", "a", "d")},
+			{"LLEN k", ":4\r\n"},
+			{"LINDEX k 0", "$1\r\nc\r\n"},
+			{"LINDEX k -1", "$1\r\nd\r\n"},
+			{"LINDEX k 10", "$-1\r\n"},
+		},
+		"ranges": {
+			{"RPUSH k a b c d", ":4\r\n"},
+			{"LRANGE k 1 -2", bulks("b", "c")},
+			{"LRANGE k -100 100", bulks("a", "b", "c", "d")},
+			{"LRANGE k 5 10", "*0\r\n"},
+			{"LRANGE k 2 1", "*0\r\n"},
+			{"LRANGE k x 1", "-ERR value is not an integer or out of range\r\n"},
+		},
+		"missing key": {
+			{"LRANGE k 0 -1", "*0\r\n"},
+			{"LLEN k", ":0\r\n"},
+			{"LINDEX k 0", "$-1\r\n"},
+		},
+		"wrong number of arguments": {
+			{"LPUSH k", "-ERR wrong number of arguments for 'lpush' command\r\n"},
+			{"RPUSH k", "-ERR wrong number of arguments for 'rpush' command\r\n"},
+			{"LRANGE k 0", "-ERR wrong number of arguments for 'lrange' command\r\n"},
+		},
+	})
+}
+
+func TestPop(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"pops one value": {
+			{"RPUSH k a b c", ":3\r\n"},
+			{"LPOP k", "$1\r\na\r\n"},
+			{"RPOP k", "$1\r\nc\r\n"},
+			{"LLEN k", ":1\r\n"},
+		},
+		"pops with a count": {
+			{"RPUSH k a b c d", ":4\r\n"},
+			{"LPOP k 2", bulks("a", "b")},
+			{"RPOP k 0", "*0\r\n"},
+			{"RPOP k 5", bulks("d", "c")},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"missing key": {
+			{"LPOP k", "$-1\r\n"},
+			{"RPOP k", "$-1\r\n"},
+			{"LPOP k 2", "*-1\r\n"},
+		},
+		"invalid count": {
+			{"LPOP k -1", "-ERR value is out of range, must be positive\r\n"},
+			{"RPOP k x", "-ERR value is out of range, must be positive\r\n"},
+			{"LPOP k 1 2", "-ERR wrong number of arguments for 'lpop' command\r\n"},
+		},
+	})
+}
+
+func TestLInsert(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"inserts around the pivot": {
+			{"RPUSH k a c", ":2\r\n"},
+			{"LINSERT k BEFORE c b", ":3\r\n"},
+			{"LINSERT k after c d", ":4\r\n"},
+			{"LRANGE k 0 -1", bulks("a", "b", "c", "d")},
+		},
+		"missing pivot or key": {
+			{"RPUSH k a", ":1\r\n"},
+			{"LINSERT k BEFORE z x", ":-1\r\n"},
+			{"LINSERT missing BEFORE a b", ":0\r\n"},
+			{"EXISTS missing", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"LINSERT k MIDDLE a b", "-ERR syntax error\r\n"},
+			{"LINSERT k BEFORE a", "-ERR wrong number of arguments for 'linsert' command\r\n"},
+		},
+	})
+}
+
+func TestLRem(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"removes from the head, the tail or everywhere": {
+			{"RPUSH k a b a c a a", ":6\r\n"},
+			{"LREM k 2 a", ":2\r\n"},
+			{"LRANGE k 0 -1", bulks("b", "c", "a", "a")},
+			{"LREM k -1 a", ":1\r\n"},
+			{"LRANGE k 0 -1", bulks("b", "c", "a")},
+			{"LREM k 0 a", ":1\r\n"},
+			{"LREM k 0 z", ":0\r\n"},
+			{"LRANGE k 0 -1", bulks("b", "c")},
+		},
+		"removes the emptied list": {
+			{"RPUSH k a a", ":2\r\n"},
+			{"LREM k 0 a", ":2\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"LREM missing 0 a", ":0\r\n"},
+			{"LREM k x a", "-ERR value is not an integer or out of range\r\n"},
+			{"LREM k 0", "-ERR wrong number of arguments for 'lrem' command\r\n"},
+		},
+	})
+}
+
+func TestLTrim(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"trims": {
+			{"RPUSH k a b c d e", ":5\r\n"},
+			{"LTRIM k 1 -2", "+OK\r\n"},
+			{"LRANGE k 0 -1", bulks("b", "c", "d")},
+			{"LTRIM k -100 100", "+OK\r\n"},
+			{"LLEN k", ":3\r\n"},
+		},
+		"removes the emptied list": {
+			{"RPUSH k a b", ":2\r\n"},
+			{"LTRIM k 5 10", "+OK\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"LTRIM missing 0 1", "+OK\r\n"},
+			{"LTRIM k x 1", "-ERR value is not an integer or out of range\r\n"},
+			{"LTRIM k 0", "-ERR wrong number of arguments for 'ltrim' command\r\n"},
+		},
+	})
+}
+
+func TestLSet(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"sets by index": {
+			{"RPUSH k a b c", ":3\r\n"},
+			{"LSET k 1 x", "+OK\r\n"},
+			{"LSET k -1 y", "+OK\r\n"},
+			{"LRANGE k 0 -1", bulks("a", "x", "y")},
+		},
+		"errors": {
+			{"LSET missing 0 v", "-ERR no such key\r\n"},
+			{"RPUSH k a", ":1\r\n"},
+			{"LSET k 1 v", "-ERR index out of range\r\n"},
+			{"LSET k -2 v", "-ERR index out of range\r\n"},
+			{"LSET k x v", "-ERR value is not an integer or out of range\r\n"},
+			{"LSET k 0", "-ERR wrong number of arguments for 'lset' command\r\n"},
+		},
+	})
+}
+
+func TestLMove(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"moves between lists": {
+			{"RPUSH src a b c", ":3\r\n"},
+			{"LMOVE src dst LEFT RIGHT", "$1\r\na\r\n"},
+			{"LMOVE src dst right left", "$1\r\nc\r\n"},
+			{"LRANGE dst 0 -1", bulks("c", "a")},
+			{"LMOVE src dst LEFT LEFT", "$1\r\nb\r\n"},
+			{"EXISTS src", ":0\r\n"},
+			{"LRANGE dst 0 -1", bulks("b", "c", "a")},
+		},
+		"rotates a list": {
+			{"RPUSH k a b c", ":3\r\n"},
+			{"LMOVE k k LEFT RIGHT", "$1\r\na\r\n"},
+			{"LRANGE k 0 -1", bulks("b", "c", "a")},
+		},
+		"missing source": {
+			{"LMOVE src dst LEFT LEFT", "$-1\r\n"},
+			{"EXISTS dst", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"LMOVE src dst UP LEFT", "-ERR syntax error\r\n"},
+			{"LMOVE src dst LEFT", "-ERR wrong number of arguments for 'lmove' command\r\n"},
+		},
+	})
+}
+
+func TestListWrongType(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"string key": {
+			{"SET s v", "+OK\r\n"},
+			{"LPUSH s a", wrongTypeReply},
+			{"RPUSH s a", wrongTypeReply},
+			{"LPOP s", wrongTypeReply},
+			{"RPOP s 2", wrongTypeReply},
+			{"LLEN s", wrongTypeReply},
+			{"LRANGE s 0 -1", wrongTypeReply},
+			{"LINDEX s 0", wrongTypeReply},
+			{"LINSERT s BEFORE a b", wrongTypeReply},
+			{"LREM s 0 a", wrongTypeReply},
+			{"LTRIM s 0 1", wrongTypeReply},
+			{"LSET s 0 a", wrongTypeReply},
+			{"LMOVE s dst LEFT LEFT", wrongTypeReply},
+			{"GET s", "$1\r\nv\r\n"},
+		},
+		"hash key": {
+			{"HSET h f v", ":1\r\n"},
+			{"LPUSH h a", wrongTypeReply},
+			{"RPUSH h a", wrongTypeReply},
+			{"LPOP h", wrongTypeReply},
+			{"LLEN h", wrongTypeReply},
+			{"LRANGE h 0 -1", wrongTypeReply},
+			{"LSET h 0 a", wrongTypeReply},
+			{"HGET h f", "$1\r\nv\r\n"},
+		},
+		"destination of another type": {
+			{"RPUSH src a", ":1\r\n"},
+			{"HSET h f v", ":1\r\n"},
+			{"LMOVE src h LEFT LEFT", wrongTypeReply},
+			{"LLEN src", ":1\r\n"},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type LLenHandler struct {
+}
+
+func NewLLenHandler() Handler {
+	return &LLenHandler{}
+}
+
+func (h *LLenHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(LLEN)
+	}
+
+	var length int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, ok, err := tx.GetList(argString(args[0]))
+		if ok {
+			length = l.Len()
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(length)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type LMoveHandler struct {
+}
+
+func NewLMoveHandler() Handler {
+	return &LMoveHandler{}
+}
+
+// argSide parses the LEFT or RIGHT argument of LMOVE and reports whether it
+// names the head of the list.
+func argSide(arg resp.Expression) (left bool, ok bool) {
+	switch strings.ToUpper(argString(arg)) {
+	case "LEFT":
+		return true, true
+	case "RIGHT":
+		return false, true
+	}
+	return false, false
+}
+
+// Handle implements LMOVE source destination LEFT|RIGHT LEFT|RIGHT. The value
+// is popped and pushed in a single transaction, so other clients never see it
+// in both lists or in none.
+func (h *LMoveHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 4 {
+		return newWrongNumberOfArgsError(LMOVE)
+	}
+
+	fromLeft, fromOK := argSide(args[2])
+	toLeft, toOK := argSide(args[3])
+	if !fromOK || !toOK {
+		return newSyntaxError()
+	}
+
+	destination := argString(args[1])
+	var value string
+	var moved bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		var err error
+		value, moved, err = moveValue(tx, argString(args[0]), destination, fromLeft, toLeft)
+		if moved {
+			ctx.Blocked.serve(tx, destination)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !moved {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(value)
+}
+
+// moveValue pops a value from one end of the list at source and pushes it to
+// one end of the list at destination. Nothing is moved if either key holds
+// another type.
+func moveValue(tx keyspace.Tx, source, destination string, fromLeft, toLeft bool) (string, bool, error) {
+	l, ok, err := tx.GetList(source)
+	if !ok {
+		return "", false, err
+	}
+	dst, err := tx.GetOrCreateList(destination)
+	if err != nil {
+		return "", false, err
+	}
+
+	var popped []string
+	if fromLeft {
+		popped = l.PopFront(1)
+	} else {
+		popped = l.PopBack(1)
+	}
+	if toLeft {
+		dst.PushFront(popped...)
+	} else {
+		dst.PushBack(popped...)
+	}
+	return popped[0], true, nil
+}
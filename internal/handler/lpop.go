@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/list"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// PopHandler implements LPOP and RPOP. Without a count they reply with a
+// single value, or nil when the list does not exist.
+type PopHandler struct {
+	cmd string
+	pop func(l *list.List, count int) []string
+}
+
+func NewLPopHandler() Handler {
+	return &PopHandler{cmd: LPOP, pop: (*list.List).PopFront}
+}
+
+func NewRPopHandler() Handler {
+	return &PopHandler{cmd: RPOP, pop: (*list.List).PopBack}
+}
+
+func (h *PopHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 || len(args) > 2 {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	count := int64(1)
+	if len(args) == 2 {
+		var ok bool
+		count, ok = argInt(args[1])
+		if !ok || count < 0 {
+			return resp.NewErrorExpression("ERR value is out of range, must be positive")
+		}
+	}
+
+	var popped []string
+	var found bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, ok, err := tx.GetList(argString(args[0]))
+		if ok {
+			popped, found = h.pop(l, int(count)), true
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+
+	if len(args) == 2 {
+		if !found {
+			return resp.NewNullArrayExpression()
+		}
+		return resp.NewBulkStringArrayExpression(popped)
+	}
+	if len(popped) == 0 {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(popped[0])
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// PushHandler implements LPUSH and RPUSH, which reply with the length of the
+// list after the push.
+type PushHandler struct {
+	cmd   string
+	front bool
+}
+
+func NewLPushHandler() Handler {
+	return &PushHandler{cmd: LPUSH, front: true}
+}
+
+func NewRPushHandler() Handler {
+	return &PushHandler{cmd: RPUSH}
+}
+
+func (h *PushHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	key := argString(args[0])
+	values := argStrings(args[1:])
+	var length int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, err := tx.GetOrCreateList(key)
+		if err != nil {
+			return err
+		}
+		if h.front {
+			length = l.PushFront(values...)
+		} else {
+			length = l.PushBack(values...)
+		}
+		ctx.Blocked.serve(tx, key)
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(length)
+}
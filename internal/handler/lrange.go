@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type LRangeHandler struct {
+}
+
+func NewLRangeHandler() Handler {
+	return &LRangeHandler{}
+}
+
+func (h *LRangeHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(LRANGE)
+	}
+
+	start, startOK := argInt(args[1])
+	stop, stopOK := argInt(args[2])
+	if !startOK || !stopOK {
+		return newNotIntegerError()
+	}
+
+	var values []string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, ok, err := tx.GetList(argString(args[0]))
+		if ok {
+			values = l.Range(int(start), int(stop))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewBulkStringArrayExpression(values)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type LRemHandler struct {
+}
+
+func NewLRemHandler() Handler {
+	return &LRemHandler{}
+}
+
+func (h *LRemHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(LREM)
+	}
+
+	count, ok := argInt(args[1])
+	if !ok {
+		return newNotIntegerError()
+	}
+
+	var removed int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, ok, err := tx.GetList(argString(args[0]))
+		if ok {
+			removed = l.Remove(int(count), argString(args[2]))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(removed)
+}
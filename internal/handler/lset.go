@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type LSetHandler struct {
+}
+
+func NewLSetHandler() Handler {
+	return &LSetHandler{}
+}
+
+func (h *LSetHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(LSET)
+	}
+
+	index, ok := argInt(args[1])
+	if !ok {
+		return newNotIntegerError()
+	}
+
+	var found, set bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, ok, err := tx.GetList(argString(args[0]))
+		if ok {
+			found, set = true, l.Set(int(index), argString(args[2]))
+		}
+		return err
+	})
+
+	switch {
+	case err != nil:
+		return newErrorExpression(err)
+	case !found:
+		return resp.NewErrorExpression("ERR no such key")
+	case !set:
+		return resp.NewErrorExpression("ERR index out of range")
+	}
+	return resp.NewSimpleStringExpression("OK")
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type LTrimHandler struct {
+}
+
+func NewLTrimHandler() Handler {
+	return &LTrimHandler{}
+}
+
+func (h *LTrimHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(LTRIM)
+	}
+
+	start, startOK := argInt(args[1])
+	stop, stopOK := argInt(args[2])
+	if !startOK || !stopOK {
+		return newNotIntegerError()
+	}
+
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		l, ok, err := tx.GetList(argString(args[0]))
+		if ok {
+			l.Trim(int(start), int(stop))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewSimpleStringExpression("OK")
+}
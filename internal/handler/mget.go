@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type MGetHandler struct {
+}
+
+func NewMGetHandler() Handler {
+	return &MGetHandler{}
+}
+
+// Handle implements MGET key [key ...]. A key that does not hold a string is
+// replied with nil, as a missing key is.
+func (h *MGetHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(MGET)
+	}
+
+	values := make([]resp.Expression, 0, len(args))
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		for _, key := range argStrings(args) {
+			value, ok, _ := tx.GetString(key)
+			if !ok {
+				values = append(values, resp.NewNullBulkStringExpression())
+				continue
+			}
+			values = append(values, resp.NewBulkStringExpression(value))
+		}
+		return nil
+	})
+	return resp.NewArrayExpression(values...)
+}
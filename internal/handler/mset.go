@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// MSetHandler implements MSET and MSETNX, which set several strings in a
+// single update of the keyspace. MSETNX sets none of them if any key exists.
+type MSetHandler struct {
+	cmd string
+	nx  bool
+}
+
+func NewMSetHandler() Handler {
+	return &MSetHandler{cmd: MSET}
+}
+
+func NewMSetNXHandler() Handler {
+	return &MSetHandler{cmd: MSETNX, nx: true}
+}
+
+func (h *MSetHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 || len(args)%2 != 0 {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	set := true
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		if h.nx {
+			for i := 0; i < len(args); i += 2 {
+				if tx.Exists(argString(args[i])) {
+					set = false
+					return nil
+				}
+			}
+		}
+
+		for i := 0; i < len(args); i += 2 {
+			tx.SetString(argString(args[i]), argString(args[i+1]))
+		}
+		return nil
+	})
+
+	if !h.nx {
+		return resp.NewSimpleStringExpression("OK")
+	}
+	if !set {
+		return resp.NewIntegerExpression(0)
+	}
+	return resp.NewIntegerExpression(1)
+}
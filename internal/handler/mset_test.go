@@ -0,0 +1,45 @@
+package handler
+
+import "testing"
+
+func TestMSetAndMGet(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"MSET": {
+			{"MSET a 1 b 2", "+OK\r\n"},
+			{"MGET a b c", "*3\r\n$1\r\n1\r\n$1\r\n2\r\n$-1\r\n"},
+			{"MSET a 1 b", "-ERR wrong number of arguments for 'mset' command\r\n"},
+			{"MSET a 3 a 4", "+OK\r\n"},
+			{"GET a", "$1\r\n4\r\n"},
+		},
+		"MGET replies nil for other types": {
+			{"SET a 1", "+OK\r\n"},
+			{"RPUSH l x", ":1\r\n"},
+			{"MGET a l", "*2\r\n$1\r\n1\r\n$-1\r\n"},
+			{"MGET", "-ERR wrong number of arguments for 'mget' command\r\n"},
+		},
+		"MSETNX": {
+			{"MSETNX a 1 b 2", ":1\r\n"},
+			{"MSETNX b 3 c 4", ":0\r\n"},
+			{"MGET a b c", "*3\r\n$1\r\n1\r\n$1\r\n2\r\n$-1\r\n"},
+			{"MSETNX c 5 c 6", ":1\r\n"},
+			{"GET c", "$1\r\n6\r\n"},
+			{"MSETNX a", "-ERR wrong number of arguments for 'msetnx' command\r\n"},
+		},
+		"MSETNX sets nothing if any key holds another type": {
+			{"RPUSH l x", ":1\r\n"},
+			{"MSETNX a 1 l 2", ":0\r\n"},
+			{"EXISTS a", ":0\r\n"},
+			{"LLEN l", ":1\r\n"},
+		},
+		"MSET replaces other types": {
+			{"RPUSH l x", ":1\r\n"},
+			{"MSET l v", "+OK\r\n"},
+			{"TYPE l", "+string\r\n"},
+		},
+		"MSET clears the time to live": {
+			{"SET a 1 EX 100", "+OK\r\n"},
+			{"MSET a 2", "+OK\r\n"},
+			{"TTL a", ":-1\r\n"},
+		},
+	})
+}
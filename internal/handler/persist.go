@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type PersistHandler struct {
+}
+
+func NewPersistHandler() Handler {
+	return &PersistHandler{}
+}
+
+// Handle implements PERSIST key, which replies with 1 if the key had a time to
+// live and 0 otherwise.
+func (h *PersistHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(PERSIST)
+	}
+
+	key := argString(args[0])
+	persisted := false
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		if at, ok := tx.ExpireTime(key); ok && !at.IsZero() {
+			persisted = tx.Expire(key, 0)
+		}
+		return nil
+	})
+	if !persisted {
+		return resp.NewIntegerExpression(0)
+	}
+	return resp.NewIntegerExpression(1)
+}
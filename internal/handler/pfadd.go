@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/hyperloglog"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type PFAddHandler struct {
+}
+
+func NewPFAddHandler() Handler {
+	return &PFAddHandler{}
+}
+
+// Handle implements PFADD key [element ...], which replies with 1 if the key
+// was created or its estimated cardinality may have changed, and 0 otherwise.
+func (h *PFAddHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(PFADD)
+	}
+
+	key := argString(args[0])
+	changed := false
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		hll, ok, err := getHyperLogLog(tx, key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			hll, changed = hyperloglog.New(), true
+		}
+
+		if hll.Add(argStrings(args[1:])...) {
+			changed = true
+		}
+		if changed {
+			setStringKeepTTL(tx, key, string(hll.Bytes()))
+		}
+		return nil
+	})
+	if err != nil {
+		return newHyperLogLogErrorExpression(err)
+	}
+	if !changed {
+		return resp.NewIntegerExpression(0)
+	}
+	return resp.NewIntegerExpression(1)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/hyperloglog"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type PFCountHandler struct {
+}
+
+func NewPFCountHandler() Handler {
+	return &PFCountHandler{}
+}
+
+// Handle implements PFCOUNT key [key ...]. Given several keys, it estimates
+// the cardinality of their union, merging them on the fly without changing
+// them. Given one, it caches the estimate in the string, as Redis does.
+func (h *PFCountHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(PFCOUNT)
+	}
+
+	var count uint64
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		hlls := make([]*hyperloglog.HyperLogLog, 0, len(args))
+		for _, key := range argStrings(args) {
+			hll, ok, err := getHyperLogLog(tx, key)
+			if err != nil {
+				return err
+			}
+			if ok {
+				hlls = append(hlls, hll)
+			}
+		}
+
+		if len(args) > 1 || len(hlls) == 0 {
+			count = hyperloglog.Count(hlls...)
+			return nil
+		}
+		count = hlls[0].Count()
+		setStringKeepTTL(tx, argString(args[0]), string(hlls[0].Bytes()))
+		return nil
+	})
+	if err != nil {
+		return newHyperLogLogErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(int(count))
+}
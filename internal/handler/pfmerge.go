@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/hyperloglog"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type PFMergeHandler struct {
+}
+
+func NewPFMergeHandler() Handler {
+	return &PFMergeHandler{}
+}
+
+// Handle implements PFMERGE destkey [sourcekey ...], which stores at destkey
+// the union of the HyperLogLogs at the source keys and at destkey itself.
+func (h *PFMergeHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(PFMERGE)
+	}
+
+	destination := argString(args[0])
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		merged, ok, err := getHyperLogLog(tx, destination)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			merged = hyperloglog.New()
+		}
+
+		sources := make([]*hyperloglog.HyperLogLog, 0, len(args)-1)
+		for _, key := range argStrings(args[1:]) {
+			hll, ok, err := getHyperLogLog(tx, key)
+			if err != nil {
+				return err
+			}
+			if ok {
+				sources = append(sources, hll)
+			}
+		}
+
+		merged.Merge(sources...)
+		setStringKeepTTL(tx, destination, string(merged.Bytes()))
+		return nil
+	})
+	if err != nil {
+		return newHyperLogLogErrorExpression(err)
+	}
+	return resp.NewSimpleStringExpression("OK")
+}
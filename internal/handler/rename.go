@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type RenameHandler struct {
+}
+
+func NewRenameHandler() Handler {
+	return &RenameHandler{}
+}
+
+// Handle implements RENAME key newkey, which moves a value of any type along
+// with its time to live, replacing any value at newkey.
+func (h *RenameHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(RENAME)
+	}
+
+	destination := argString(args[1])
+	renamed := false
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		renamed = tx.Rename(argString(args[0]), destination)
+		// Clients blocked on newkey are served if it now holds a list.
+		if renamed {
+			ctx.Blocked.serve(tx, destination)
+		}
+		return nil
+	})
+	if !renamed {
+		return resp.NewErrorExpression("ERR no such key")
+	}
+	return resp.NewSimpleStringExpression("OK")
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SAddHandler struct {
+}
+
+func NewSAddHandler() Handler {
+	return &SAddHandler{}
+}
+
+func (h *SAddHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 {
+		return newWrongNumberOfArgsError(SADD)
+	}
+
+	members := argStrings(args[1:])
+	var added int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		s, err := tx.GetOrCreateSet(argString(args[0]))
+		if err != nil {
+			return err
+		}
+		added = s.Add(members...)
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(added)
+}
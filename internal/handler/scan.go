@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type ScanHandler struct {
+}
+
+func NewScanHandler() Handler {
+	return &ScanHandler{}
+}
+
+// Handle implements SCAN cursor [MATCH pattern] [COUNT count] [TYPE type].
+// As in Redis, COUNT bounds the keys looked at rather than the keys returned,
+// which are filtered by MATCH and TYPE afterwards.
+func (h *ScanHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 {
+		return newWrongNumberOfArgsError(SCAN)
+	}
+
+	cursor, err := strconv.ParseUint(argString(args[0]), 10, 64)
+	if err != nil {
+		return resp.NewErrorExpression("ERR invalid cursor")
+	}
+
+	pattern, count := "*", int64(10)
+	var typ keyspace.Type
+	for i := 1; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			return newSyntaxError()
+		}
+		switch strings.ToUpper(argString(args[i])) {
+		case "MATCH":
+			pattern = argString(args[i+1])
+		case "COUNT":
+			var ok bool
+			if count, ok = argInt(args[i+1]); !ok {
+				return newNotIntegerError()
+			}
+			if count < 1 {
+				return newSyntaxError()
+			}
+		case "TYPE":
+			typ = keyspace.Type(strings.ToLower(argString(args[i+1])))
+		default:
+			return newSyntaxError()
+		}
+	}
+
+	keys := make([]string, 0)
+	var next uint64
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		var scanned []string
+		scanned, next = tx.Scan(cursor, int(count))
+		for _, key := range scanned {
+			if keyspace.Match(pattern, key) && (typ == "" || tx.Type(key) == typ) {
+				keys = append(keys, key)
+			}
+		}
+		return nil
+	})
+	return resp.NewArrayExpression(
+		resp.NewBulkStringExpression(strconv.FormatUint(next, 10)),
+		resp.NewBulkStringArrayExpression(keys),
+	)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SCardHandler struct {
+}
+
+func NewSCardHandler() Handler {
+	return &SCardHandler{}
+}
+
+func (h *SCardHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(SCARD)
+	}
+
+	card := 0
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		s, ok, err := tx.GetSet(argString(args[0]))
+		if ok {
+			card = s.Card()
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(card)
+}
@@ -1,6 +1,12 @@
 package handler
 
-import "github.com/trinhdaiphuc/go-memcache/resp"
+import (
+	"strings"
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
 
 type SetHandler struct {
 }
@@ -9,9 +15,75 @@ func NewSetHandler() Handler {
 	return &SetHandler{}
 }
 
+// Handle implements SET key value [NX | XX] [GET] [EX seconds | PX
+// milliseconds | EXAT unix-time-seconds | PXAT unix-time-milliseconds |
+// KEEPTTL]. The conditions, the write and the read of the old value happen in
+// a single update of the keyspace.
 func (s *SetHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
-	key := args[0].Value().(string)
-	value := args[1].Value().(string)
-	ctx.Map.Set(key, value)
+	if len(args) < 2 {
+		return newWrongNumberOfArgsError(SET)
+	}
+
+	var nx, xx, get, keepTTL, expires bool
+	var expireAt time.Time
+	for i := 2; i < len(args); i++ {
+		option := strings.ToUpper(argString(args[i]))
+		switch opt, isExpire := expireOptions[option]; {
+		case option == "NX" && !xx:
+			nx = true
+		case option == "XX" && !nx:
+			xx = true
+		case option == "GET":
+			get = true
+		case option == "KEEPTTL" && !expires:
+			keepTTL = true
+		case isExpire && !keepTTL && !expires && i+1 < len(args):
+			i++
+			var errReply resp.Expression
+			expireAt, errReply = argExpireTime(SET, opt, args[i])
+			if errReply != nil {
+				return errReply
+			}
+			expires = true
+		default:
+			return newSyntaxError()
+		}
+	}
+
+	key, value := argString(args[0]), argString(args[1])
+	var old string
+	var found, written bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		if get {
+			var err error
+			if old, found, err = tx.GetString(key); err != nil {
+				return err
+			}
+		}
+
+		exists := tx.Exists(key)
+		if (nx && exists) || (xx && !exists) {
+			return nil
+		}
+
+		if keepTTL {
+			expireAt, _ = tx.ExpireTime(key)
+		}
+		tx.SetString(key, value)
+		tx.ExpireAt(key, expireAt)
+		written = true
+		return nil
+	})
+
+	switch {
+	case err != nil:
+		return newErrorExpression(err)
+	case get && !found:
+		return resp.NewNullBulkStringExpression()
+	case get:
+		return resp.NewBulkStringExpression(old)
+	case !written:
+		return resp.NewNullBulkStringExpression()
+	}
 	return resp.NewSimpleStringExpression("OK")
 }
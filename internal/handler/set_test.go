@@ -0,0 +1,156 @@
+package handler
+
+import "testing"
+
+func TestSet(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"plain": {
+			{"SET k v", "+OK\r\n"},
+			{"GET k", "$1\r\nv\r\n"},
+			{"TTL k", ":-1\r\n"},
+		},
+		"replaces any type": {
+			{"RPUSH k a", ":1\r\n"},
+			{"SET k v", "+OK\r\n"},
+			{"TYPE k", "+string\r\n"},
+		},
+		"NX and XX": {
+			{"SET k v XX", "$-1\r\n"},
+			{"EXISTS k", ":0\r\n"},
+			{"SET k v NX", "+OK\r\n"},
+			{"SET k w NX", "$-1\r\n"},
+			{"SET k w xx", "+OK\r\n"},
+			{"GET k", "$1\r\nw\r\n"},
+			{"SET k v NX XX", "-ERR syntax error\r\n"},
+		},
+		"GET": {
+			{"SET k v GET", "$-1\r\n"},
+			{"SET k w GET", "$1\r\nv\r\n"},
+			{"SET k x NX GET", "$1\r\nw\r\n"},
+			{"GET k", "$1\r\nw\r\n"},
+			{"HSET h f v", ":1\r\n"},
+			{"SET h v GET", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"TYPE h", "+hash\r\n"},
+		},
+		"expire options": {
+			{"SET k v EX 100", "+OK\r\n"},
+			{"TTL k", ":100\r\n"},
+			{"SET k v PX 100000", "+OK\r\n"},
+			{"TTL k", ":100\r\n"},
+			{"SET k v EXAT 4102444800", "+OK\r\n"},
+			{"PERSIST k", ":1\r\n"},
+		},
+		"EXAT in the past expires the key": {
+			{"SET k v EXAT 1", "+OK\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"KEEPTTL": {
+			{"SET k v EX 100", "+OK\r\n"},
+			{"SET k w KEEPTTL", "+OK\r\n"},
+			{"TTL k", ":100\r\n"},
+			{"SET k x", "+OK\r\n"},
+			{"TTL k", ":-1\r\n"},
+			{"SET k v KEEPTTL EX 10", "-ERR syntax error\r\n"},
+			{"SET k v EX 10 PX 10", "-ERR syntax error\r\n"},
+		},
+		"invalid expire time": {
+			{"SET k v EX 0", "-ERR invalid expire time in 'set' command\r\n"},
+			{"SET k v PX -1", "-ERR invalid expire time in 'set' command\r\n"},
+			{"SET k v EX 9223372036854775807", "-ERR invalid expire time in 'set' command\r\n"},
+			{"SET k v EX ten", "-ERR value is not an integer or out of range\r\n"},
+			{"SET k v EX", "-ERR syntax error\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"wrong number of arguments": {
+			{"SET k", "-ERR wrong number of arguments for 'set' command\r\n"},
+		},
+	})
+}
+
+func TestSetNX(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"sets missing keys only": {
+			{"SETNX k v", ":1\r\n"},
+			{"SETNX k w", ":0\r\n"},
+			{"GET k", "$1\r\nv\r\n"},
+		},
+		"any type counts as existing": {
+			{"SADD k a", ":1\r\n"},
+			{"SETNX k v", ":0\r\n"},
+		},
+	})
+}
+
+func TestSetEx(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"SETEX": {
+			{"SETEX k 100 v", "+OK\r\n"},
+			{"GET k", "$1\r\nv\r\n"},
+			{"TTL k", ":100\r\n"},
+		},
+		"PSETEX": {
+			{"PSETEX k 100000 v", "+OK\r\n"},
+			{"TTL k", ":100\r\n"},
+		},
+		"invalid expire time": {
+			{"SETEX k 0 v", "-ERR invalid expire time in 'setex' command\r\n"},
+			{"PSETEX k -5 v", "-ERR invalid expire time in 'psetex' command\r\n"},
+			{"SETEX k x v", "-ERR value is not an integer or out of range\r\n"},
+			{"SETEX k 10", "-ERR wrong number of arguments for 'setex' command\r\n"},
+		},
+	})
+}
+
+func TestGetDel(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"deletes the string": {
+			{"SET k v", "+OK\r\n"},
+			{"GETDEL k", "$1\r\nv\r\n"},
+			{"GETDEL k", "$-1\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"wrong type": {
+			{"RPUSH k a", ":1\r\n"},
+			{"GETDEL k", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+			{"EXISTS k", ":1\r\n"},
+		},
+	})
+}
+
+func TestGetEx(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"missing key": {
+			{"GETEX k EX 10", "$-1\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"changes the time to live": {
+			{"SET k v", "+OK\r\n"},
+			{"GETEX k", "$1\r\nv\r\n"},
+			{"TTL k", ":-1\r\n"},
+			{"GETEX k EX 100", "$1\r\nv\r\n"},
+			{"TTL k", ":100\r\n"},
+			{"GETEX k PX 50000", "$1\r\nv\r\n"},
+			{"TTL k", ":50\r\n"},
+			{"GETEX k persist", "$1\r\nv\r\n"},
+			{"TTL k", ":-1\r\n"},
+		},
+		"EXAT in the past expires the key": {
+			{"SET k v", "+OK\r\n"},
+			{"GETEX k PXAT 1", "$1\r\nv\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"invalid options": {
+			{"SET k v", "+OK\r\n"},
+			{"GETEX k EX", "-ERR syntax error\r\n"},
+			{"GETEX k KEEPTTL", "-ERR syntax error\r\n"},
+			{"GETEX k EX 10 PERSIST", "-ERR syntax error\r\n"},
+			{"GETEX k EX 0", "-ERR invalid expire time in 'getex' command\r\n"},
+			{"GETEX k EX x", "-ERR value is not an integer or out of range\r\n"},
+			{"GETEX", "-ERR wrong number of arguments for 'getex' command\r\n"},
+		},
+		"wrong type": {
+			{"SADD k a", ":1\r\n"},
+			{"GETEX k EX 10", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/bitmap"
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SetBitHandler struct {
+}
+
+func NewSetBitHandler() Handler {
+	return &SetBitHandler{}
+}
+
+// Handle implements SETBIT key offset value, which replies with the previous
+// bit and keeps the time to live of the string.
+func (h *SetBitHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(SETBIT)
+	}
+
+	offset, ok := argBitOffset(args[1])
+	if !ok {
+		return newBitOffsetError()
+	}
+	bit, ok := argInt(args[2])
+	if !ok || (bit != 0 && bit != 1) {
+		return resp.NewErrorExpression("ERR bit is not an integer or out of range")
+	}
+
+	key := argString(args[0])
+	var old int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, _, err := tx.GetBytes(key)
+		if err != nil {
+			return err
+		}
+
+		// The bit is set in place, so the string is only stored again when
+		// it grows.
+		var b []byte
+		b, old = bitmap.SetBit(value, offset, int(bit))
+		if len(b) != len(value) {
+			setBytesKeepTTL(tx, key, b)
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(old)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// SetExHandler implements SETEX and PSETEX, which set a string along with its
+// time to live in seconds or milliseconds.
+type SetExHandler struct {
+	cmd string
+	opt expireOption
+}
+
+func NewSetExHandler() Handler {
+	return &SetExHandler{cmd: SETEX, opt: expireOptions["EX"]}
+}
+
+func NewPSetExHandler() Handler {
+	return &SetExHandler{cmd: PSETEX, opt: expireOptions["PX"]}
+}
+
+func (h *SetExHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	expireAt, errReply := argExpireTime(h.cmd, h.opt, args[1])
+	if errReply != nil {
+		return errReply
+	}
+
+	key := argString(args[0])
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		tx.SetString(key, argString(args[2]))
+		tx.ExpireAt(key, expireAt)
+		return nil
+	})
+	return resp.NewSimpleStringExpression("OK")
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SetNXHandler struct {
+}
+
+func NewSetNXHandler() Handler {
+	return &SetNXHandler{}
+}
+
+func (h *SetNXHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(SETNX)
+	}
+
+	key := argString(args[0])
+	set := false
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		if !tx.Exists(key) {
+			tx.SetString(key, argString(args[1]))
+			set = true
+		}
+		return nil
+	})
+	if !set {
+		return resp.NewIntegerExpression(0)
+	}
+	return resp.NewIntegerExpression(1)
+}
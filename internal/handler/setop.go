@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+	"github.com/trinhdaiphuc/go-memcache/set"
+)
+
+// SetOperationHandler implements SUNION, SINTER and SDIFF, and their STORE
+// variants which write the result to a destination key given first.
+type SetOperationHandler struct {
+	cmd   string
+	op    func(sets ...*set.Set[string]) []string
+	store bool
+}
+
+func NewSUnionHandler() Handler {
+	return &SetOperationHandler{cmd: SUNION, op: set.Union[string]}
+}
+
+func NewSInterHandler() Handler {
+	return &SetOperationHandler{cmd: SINTER, op: set.Intersect[string]}
+}
+
+func NewSDiffHandler() Handler {
+	return &SetOperationHandler{cmd: SDIFF, op: set.Diff[string]}
+}
+
+func NewSUnionStoreHandler() Handler {
+	return &SetOperationHandler{cmd: SUNIONSTORE, op: set.Union[string], store: true}
+}
+
+func NewSInterStoreHandler() Handler {
+	return &SetOperationHandler{cmd: SINTERSTORE, op: set.Intersect[string], store: true}
+}
+
+func NewSDiffStoreHandler() Handler {
+	return &SetOperationHandler{cmd: SDIFFSTORE, op: set.Diff[string], store: true}
+}
+
+func (h *SetOperationHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	keys := args
+	if h.store {
+		keys = args[min(1, len(args)):]
+	}
+	if len(keys) < 1 {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	var members []string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		sets := make([]*set.Set[string], 0, len(keys))
+		for _, key := range keys {
+			s, ok, err := tx.GetSet(argString(key))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				// A missing key is an empty set.
+				s = set.NewSet[string]()
+			}
+			sets = append(sets, s)
+		}
+		members = h.op(sets...)
+
+		if h.store {
+			destination := argString(args[0])
+			tx.Delete(destination)
+			if len(members) > 0 {
+				s, _ := tx.GetOrCreateSet(destination)
+				s.Add(members...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+
+	if h.store {
+		return resp.NewIntegerExpression(len(members))
+	}
+	return resp.NewBulkStringArrayExpression(members)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SetRangeHandler struct {
+}
+
+func NewSetRangeHandler() Handler {
+	return &SetRangeHandler{}
+}
+
+// Handle implements SETRANGE key offset value, which overwrites the string
+// from offset on, padding it with zero bytes if it is shorter, and replies
+// with its new length.
+func (h *SetRangeHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(SETRANGE)
+	}
+
+	offset, ok := argInt(args[1])
+	if !ok {
+		return newNotIntegerError()
+	}
+	if offset < 0 {
+		return resp.NewErrorExpression("ERR offset is out of range")
+	}
+
+	key, patch := argString(args[0]), argString(args[2])
+	var length int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		value, _, err := tx.GetString(key)
+		if err != nil {
+			return err
+		}
+		// An empty value changes nothing, and does not create the key.
+		if len(patch) == 0 {
+			length = len(value)
+			return nil
+		}
+		if offset > maxStringSize-int64(len(patch)) {
+			return errStringTooLong
+		}
+
+		if end := int(offset) + len(patch); end > len(value) {
+			value += strings.Repeat("\x00", end-len(value))
+		}
+		value = value[:offset] + patch + value[int(offset)+len(patch):]
+		length = len(value)
+		setStringKeepTTL(tx, key, value)
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(length)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import "testing"
+
+func TestSetRange(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"overwrites": {
+			{`SET k "Hello World"`, "+OK\r\n"},
+			{"SETRANGE k 6 Redis", ":11\r\n"},
+			{"GET k", "$11\r\nHello Redis\r\n"},
+			{"SETRANGE k 9 Redis!", ":15\r\n"},
+			{"GET k", "$15\r\nHello RedRedis!\r\n"},
+		},
+		"pads with zero bytes": {
+			{"SETRANGE k 6 Redis", ":11\r\n"},
+			{"GET k", "$11\r\n\x00\x00\x00\x00\x00\x00Redis\r\n"},
+		},
+		"empty value": {
+			{`SETRANGE k 10 ""`, ":0\r\n"},
+			{"EXISTS k", ":0\r\n"},
+			{"SET k abc", "+OK\r\n"},
+			{`SETRANGE k 10 ""`, ":3\r\n"},
+			{"GET k", "$3\r\nabc\r\n"},
+		},
+		"keeps the time to live": {
+			{"SET k v EX 100", "+OK\r\n"},
+			{"SETRANGE k 1 w", ":2\r\n"},
+			{"TTL k", ":100\r\n"},
+		},
+		"invalid offsets": {
+			{"SETRANGE k -1 v", "-ERR offset is out of range\r\n"},
+			{"SETRANGE k x v", "-ERR value is not an integer or out of range\r\n"},
+			{"SETRANGE k 536870911 vv", "-ERR string exceeds maximum allowed size (proto-max-bulk-len)\r\n"},
+			{"SETRANGE k 9223372036854775807 v", "-ERR string exceeds maximum allowed size (proto-max-bulk-len)\r\n"},
+			{"EXISTS k", ":0\r\n"},
+		},
+		"wrong type": {
+			{"SADD k a", ":1\r\n"},
+			{"SETRANGE k 0 v", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
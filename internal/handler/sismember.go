@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SIsMemberHandler struct {
+}
+
+func NewSIsMemberHandler() Handler {
+	return &SIsMemberHandler{}
+}
+
+func (h *SIsMemberHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(SISMEMBER)
+	}
+
+	found := false
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		s, ok, err := tx.GetSet(argString(args[0]))
+		if ok {
+			found = s.Contains(argString(args[1]))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !found {
+		return resp.NewIntegerExpression(0)
+	}
+	return resp.NewIntegerExpression(1)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SMembersHandler struct {
+}
+
+func NewSMembersHandler() Handler {
+	return &SMembersHandler{}
+}
+
+func (h *SMembersHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(SMEMBERS)
+	}
+
+	var members []string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		s, ok, err := tx.GetSet(argString(args[0]))
+		if ok {
+			members = s.Members()
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewBulkStringArrayExpression(members)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SPopHandler struct {
+}
+
+func NewSPopHandler() Handler {
+	return &SPopHandler{}
+}
+
+// Handle implements SPOP key [count]. Without a count it replies with a single
+// member, or nil when the set does not exist.
+func (h *SPopHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 || len(args) > 2 {
+		return newWrongNumberOfArgsError(SPOP)
+	}
+
+	count := int64(1)
+	if len(args) == 2 {
+		var ok bool
+		count, ok = argInt(args[1])
+		if !ok || count < 0 {
+			return resp.NewErrorExpression("ERR value is out of range, must be positive")
+		}
+	}
+
+	var popped []string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		s, ok, err := tx.GetSet(argString(args[0]))
+		if ok {
+			popped = s.Pop(int(count))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+
+	if len(args) == 2 {
+		return resp.NewBulkStringArrayExpression(popped)
+	}
+	if len(popped) == 0 {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(popped[0])
+}
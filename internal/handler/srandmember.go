@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SRandMemberHandler struct {
+}
+
+func NewSRandMemberHandler() Handler {
+	return &SRandMemberHandler{}
+}
+
+// Handle implements SRANDMEMBER key [count]. A positive count returns distinct
+// members, a negative one may return the same member several times.
+func (h *SRandMemberHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 || len(args) > 2 {
+		return newWrongNumberOfArgsError(SRANDMEMBER)
+	}
+
+	count := int64(1)
+	if len(args) == 2 {
+		var errReply resp.Expression
+		count, errReply = argRandomCount(args[1])
+		if errReply != nil {
+			return errReply
+		}
+	}
+
+	var members []string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		s, ok, err := tx.GetSet(argString(args[0]))
+		if ok {
+			members = s.RandomMember(int(count))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+
+	if len(args) == 2 {
+		return resp.NewBulkStringArrayExpression(members)
+	}
+	if len(members) == 0 {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(members[0])
+}
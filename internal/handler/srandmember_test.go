@@ -0,0 +1,44 @@
+package handler
+
+import "testing"
+
+func TestSRandMember(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"missing key": {
+			{"SRANDMEMBER s", "$-1\r\n"},
+			{"SRANDMEMBER s 2", "*0\r\n"},
+			{"SRANDMEMBER s -2", "*0\r\n"},
+		},
+		"single member": {
+			{"SADD s a", ":1\r\n"},
+			{"SRANDMEMBER s", "$1\r\na\r\n"},
+			{"SRANDMEMBER s 3", "*1\r\n$1\r\na\r\n"},
+			{"SRANDMEMBER s -3", "*3\r\n$1\r\na\r\n$1\r\na\r\n$1\r\na\r\n"},
+			{"SCARD s", ":1\r\n"},
+		},
+		"invalid count": {
+			{"SADD s a", ":1\r\n"},
+			{"SRANDMEMBER s x", "-ERR value is not an integer or out of range\r\n"},
+			{"SRANDMEMBER s -9223372036854775808", "-ERR value is out of range, must be between -16777216 and 9223372036854775807\r\n"},
+			{"SRANDMEMBER s -1000000000000", "-ERR value is out of range, must be between -16777216 and 9223372036854775807\r\n"},
+		},
+		"wrong type": {
+			{"SET s v", "+OK\r\n"},
+			{"SRANDMEMBER s", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
+
+func TestSetOperations(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"missing keys are empty sets": {
+			{"SADD a x", ":1\r\n"},
+			{"SINTER a missing", "*0\r\n"},
+			{"SDIFF a missing", "*1\r\n$1\r\nx\r\n"},
+			{"SUNIONSTORE dst missing a", ":1\r\n"},
+			{"SMEMBERS dst", "*1\r\n$1\r\nx\r\n"},
+			{"SINTERSTORE dst a missing", ":0\r\n"},
+			{"EXISTS dst", ":0\r\n"},
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type SRemHandler struct {
+}
+
+func NewSRemHandler() Handler {
+	return &SRemHandler{}
+}
+
+func (h *SRemHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 {
+		return newWrongNumberOfArgsError(SREM)
+	}
+
+	members := argStrings(args[1:])
+	var removed int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		s, ok, err := tx.GetSet(argString(args[0]))
+		if ok {
+			removed = s.Remove(members...)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(removed)
+}
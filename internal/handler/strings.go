@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// maxStringSize is the size past which a string cannot grow, as in Redis.
+const maxStringSize = 512 << 20
+
+var errStringTooLong = errors.New("string exceeds maximum allowed size (proto-max-bulk-len)")
+
+// expireOption is an option setting the expiry of a string: EX and PX give a
+// time to live, EXAT and PXAT a Unix time.
+type expireOption struct {
+	unit     time.Duration
+	absolute bool
+}
+
+var expireOptions = map[string]expireOption{
+	"EX":   {unit: time.Second},
+	"PX":   {unit: time.Millisecond},
+	"EXAT": {unit: time.Second, absolute: true},
+	"PXAT": {unit: time.Millisecond, absolute: true},
+}
+
+// argExpireTime parses the value of an expire option of cmd into the time the
+// key expires at.
+func argExpireTime(cmd string, opt expireOption, arg resp.Expression) (time.Time, resp.Expression) {
+	value, ok := argInt(arg)
+	if !ok {
+		return time.Time{}, newNotIntegerError()
+	}
+
+	scale := int64(opt.unit / time.Millisecond)
+	if value <= 0 || value > math.MaxInt64/scale {
+		return time.Time{}, newInvalidExpireTimeError(cmd)
+	}
+	milliseconds := value * scale
+	if !opt.absolute {
+		now := time.Now().UnixMilli()
+		if milliseconds > math.MaxInt64-now {
+			return time.Time{}, newInvalidExpireTimeError(cmd)
+		}
+		milliseconds += now
+	}
+	return time.UnixMilli(milliseconds), nil
+}
+
+func newInvalidExpireTimeError(cmd string) resp.Expression {
+	return resp.NewErrorExpression("ERR invalid expire time in '" + strings.ToLower(cmd) + "' command")
+}
+
+// setStringKeepTTL stores value at key without changing its time to live, as
+// the commands modifying a string do.
+func setStringKeepTTL(tx keyspace.Tx, key, value string) {
+	setBytesKeepTTL(tx, key, []byte(value))
+}
+
+// setBytesKeepTTL is setStringKeepTTL without copying value.
+func setBytesKeepTTL(tx keyspace.Tx, key string, value []byte) {
+	at, _ := tx.ExpireTime(key)
+	tx.SetBytes(key, value)
+	tx.ExpireAt(key, at)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type StrLenHandler struct {
+}
+
+func NewStrLenHandler() Handler {
+	return &StrLenHandler{}
+}
+
+func (h *StrLenHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(STRLEN)
+	}
+
+	var value string
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		var err error
+		value, _, err = tx.GetString(argString(args[0]))
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(len(value))
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+// TTLHandler implements TTL and PTTL, which reply with the time to live of a
+// key in seconds or milliseconds, -1 if it has none and -2 if the key does not
+// exist.
+type TTLHandler struct {
+	cmd  string
+	unit time.Duration
+}
+
+func NewTTLHandler() Handler {
+	return &TTLHandler{cmd: TTL, unit: time.Second}
+}
+
+func NewPTTLHandler() Handler {
+	return &TTLHandler{cmd: PTTL, unit: time.Millisecond}
+}
+
+func (h *TTLHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	var expireAt time.Time
+	var found bool
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		expireAt, found = tx.ExpireTime(argString(args[0]))
+		return nil
+	})
+
+	switch {
+	case !found:
+		return resp.NewIntegerExpression(-2)
+	case expireAt.IsZero():
+		return resp.NewIntegerExpression(-1)
+	}
+
+	// The time left is counted in milliseconds, as a time.Duration cannot
+	// hold the farthest expire times, then rounded to the nearest unit like
+	// Redis does.
+	milliseconds := max(expireAt.UnixMilli()-time.Now().UnixMilli(), 0)
+	scale := int64(h.unit / time.Millisecond)
+	return resp.NewIntegerExpression(int((milliseconds + scale/2) / scale))
+}
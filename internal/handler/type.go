@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type TypeHandler struct {
+}
+
+func NewTypeHandler() Handler {
+	return &TypeHandler{}
+}
+
+func (h *TypeHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(TYPE)
+	}
+
+	var typ keyspace.Type
+	_ = ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		typ = tx.Type(argString(args[0]))
+		return nil
+	})
+	return resp.NewSimpleStringExpression(string(typ))
+}
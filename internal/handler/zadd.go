@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+	"github.com/trinhdaiphuc/go-memcache/sortedset"
+)
+
+type ZAddHandler struct {
+}
+
+func NewZAddHandler() Handler {
+	return &ZAddHandler{}
+}
+
+// Handle implements ZADD key [NX|XX] [GT|LT] [CH] [INCR] score member
+// [score member ...]. Every score is parsed before the set is touched, so a
+// bad one leaves it unchanged.
+func (h *ZAddHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 3 {
+		return newWrongNumberOfArgsError(ZADD)
+	}
+
+	var opts sortedset.AddOptions
+	var changed bool
+	i := 1
+flags:
+	for ; i < len(args); i++ {
+		switch strings.ToUpper(argString(args[i])) {
+		case "NX":
+			opts.NX = true
+		case "XX":
+			opts.XX = true
+		case "GT":
+			opts.GT = true
+		case "LT":
+			opts.LT = true
+		case "CH":
+			changed = true
+		case "INCR":
+			opts.Incr = true
+		default:
+			break flags
+		}
+	}
+
+	pairs := args[i:]
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return newSyntaxError()
+	}
+	if opts.NX && opts.XX {
+		return resp.NewErrorExpression("ERR XX and NX options at the same time are not compatible")
+	}
+	if (opts.GT && opts.LT) || (opts.NX && (opts.GT || opts.LT)) {
+		return resp.NewErrorExpression("ERR GT, LT, and/or NX options at the same time are not compatible")
+	}
+	if opts.Incr && len(pairs) > 2 {
+		return resp.NewErrorExpression("ERR INCR option supports a single increment-element pair")
+	}
+
+	entries := make([]sortedset.Entry, 0, len(pairs)/2)
+	for j := 0; j < len(pairs); j += 2 {
+		score, ok := argScore(pairs[j])
+		if !ok {
+			return newNotFloatError()
+		}
+		entries = append(entries, sortedset.Entry{Member: argString(pairs[j+1]), Score: score})
+	}
+
+	var count int
+	var score float64
+	var result sortedset.AddResult
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		z, err := tx.GetOrCreateZSet(argString(args[0]))
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			score, result, err = z.Add(entry.Member, entry.Score, opts)
+			if err != nil {
+				return err
+			}
+			if result == sortedset.Added || (changed && result == sortedset.Updated) {
+				count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !opts.Incr {
+		return resp.NewIntegerExpression(count)
+	}
+	if result == sortedset.Skipped {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(formatScore(score))
+}
@@ -0,0 +1,70 @@
+package handler
+
+import "testing"
+
+func TestZAdd(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"adds and updates": {
+			{"ZADD z 1 a 2 b", ":2\r\n"},
+			{"ZADD z 3 b 4 c", ":1\r\n"},
+			{"ZSCORE z b", "$1\r\n3\r\n"},
+			{"ZADD z CH 3 b 5 c 6 d", ":2\r\n"},
+			{"ZCARD z", ":4\r\n"},
+		},
+		"NX and XX": {
+			{"ZADD z 1 a", ":1\r\n"},
+			{"ZADD z NX 2 a 3 b", ":1\r\n"},
+			{"ZSCORE z a", "$1\r\n1\r\n"},
+			{"ZADD z XX CH 4 a 5 c", ":1\r\n"},
+			{"ZSCORE z a", "$1\r\n4\r\n"},
+			{"ZSCORE z c", "$-1\r\n"},
+			{"ZADD missing XX 1 a", ":0\r\n"},
+			{"EXISTS missing", ":0\r\n"},
+		},
+		"GT and LT": {
+			{"ZADD z 5 a 5 b", ":2\r\n"},
+			{"ZADD z GT CH 4 a 6 b 1 c", ":2\r\n"},
+			{"ZRANGE z 0 -1 WITHSCORES", bulks("c", "1", "a", "5", "b", "6")},
+			{"ZADD z LT CH 3 a 7 b", ":1\r\n"},
+			{"ZRANGE z 0 -1 WITHSCORES", bulks("c", "1", "a", "3", "b", "6")},
+			{"ZADD z XX GT CH 4 a 0 c 7 e", ":1\r\n"},
+			{"ZSCORE z a", "$1\r\n4\r\n"},
+			{"ZSCORE z c", "$1\r\n1\r\n"},
+			{"ZSCORE z e", "$-1\r\n"},
+		},
+		"INCR": {
+			{"ZADD z INCR 1.5 a", "$3\r\n1.5\r\n"},
+			{"ZADD z incr 2 a", "$3\r\n3.5\r\n"},
+			{"ZADD z NX INCR 1 a", "$-1\r\n"},
+			{"ZADD z XX INCR 1 b", "$-1\r\n"},
+			{"ZADD z GT INCR -1 a", "$-1\r\n"},
+			{"ZADD z LT INCR -1 a", "$3\r\n2.5\r\n"},
+			{"ZADD z 1 inf", ":1\r\n"},
+			{"ZADD z INCR -inf a", "$4\r\n-inf\r\n"},
+		},
+		"INCR resulting in NaN": {
+			{"ZADD z +inf a", ":1\r\n"},
+			{"ZADD z INCR -inf a", "-ERR resulting score is not a number (NaN)\r\n"},
+			{"ZSCORE z a", "$3\r\ninf\r\n"},
+		},
+		"conflicting flags": {
+			{"ZADD z NX XX 1 a", "-ERR XX and NX options at the same time are not compatible\r\n"},
+			{"ZADD z GT LT 1 a", "-ERR GT, LT, and/or NX options at the same time are not compatible\r\n"},
+			{"ZADD z NX GT 1 a", "-ERR GT, LT, and/or NX options at the same time are not compatible\r\n"},
+			{"ZADD z INCR 1 a 2 b", "-ERR INCR option supports a single increment-element pair\r\n"},
+			{"EXISTS z", ":0\r\n"},
+		},
+		"invalid arguments": {
+			{"ZADD z 1", "-ERR wrong number of arguments for 'zadd' command\r\n"},
+			{"ZADD z NX 1", "-ERR syntax error\r\n"},
+			{"ZADD z 1 a 2", "-ERR syntax error\r\n"},
+			{"ZADD z 1 a x b", "-ERR value is not a valid float\r\n"},
+			{"ZADD z nan a", "-ERR value is not a valid float\r\n"},
+			{"EXISTS z", ":0\r\n"},
+		},
+		"wrong type": {
+			{"SET z v", "+OK\r\n"},
+			{"ZADD z 1 a", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
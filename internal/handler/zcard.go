@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type ZCardHandler struct {
+}
+
+func NewZCardHandler() Handler {
+	return &ZCardHandler{}
+}
+
+func (h *ZCardHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 1 {
+		return newWrongNumberOfArgsError(ZCARD)
+	}
+
+	var card int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		z, ok, err := tx.GetZSet(argString(args[0]))
+		if ok {
+			card = z.Card()
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(card)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type ZCountHandler struct {
+}
+
+func NewZCountHandler() Handler {
+	return &ZCountHandler{}
+}
+
+func (h *ZCountHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(ZCOUNT)
+	}
+
+	r, ok := argScoreRange(args[1], args[2])
+	if !ok {
+		return newScoreRangeError()
+	}
+
+	var count int
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		z, ok, err := tx.GetZSet(argString(args[0]))
+		if ok {
+			count = z.Count(r)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewIntegerExpression(count)
+}
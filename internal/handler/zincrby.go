@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type ZIncrByHandler struct {
+}
+
+func NewZIncrByHandler() Handler {
+	return &ZIncrByHandler{}
+}
+
+func (h *ZIncrByHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 3 {
+		return newWrongNumberOfArgsError(ZINCRBY)
+	}
+
+	increment, ok := argScore(args[1])
+	if !ok {
+		return newNotFloatError()
+	}
+
+	var score float64
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		z, err := tx.GetOrCreateZSet(argString(args[0]))
+		if err != nil {
+			return err
+		}
+		score, err = z.IncrBy(argString(args[2]), increment)
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return resp.NewBulkStringExpression(formatScore(score))
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+	"github.com/trinhdaiphuc/go-memcache/sortedset"
+)
+
+// ZPopHandler implements ZPOPMIN and ZPOPMAX, which reply with the popped
+// members and their scores.
+type ZPopHandler struct {
+	cmd string
+	pop func(z *sortedset.SortedSet, count int) []sortedset.Entry
+}
+
+func NewZPopMinHandler() Handler {
+	return &ZPopHandler{cmd: ZPOPMIN, pop: (*sortedset.SortedSet).PopMin}
+}
+
+func NewZPopMaxHandler() Handler {
+	return &ZPopHandler{cmd: ZPOPMAX, pop: (*sortedset.SortedSet).PopMax}
+}
+
+func (h *ZPopHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 1 || len(args) > 2 {
+		return newWrongNumberOfArgsError(h.cmd)
+	}
+
+	count := int64(1)
+	if len(args) == 2 {
+		var ok bool
+		count, ok = argInt(args[1])
+		if !ok || count < 0 {
+			return resp.NewErrorExpression("ERR value is out of range, must be positive")
+		}
+	}
+
+	var popped []sortedset.Entry
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		z, ok, err := tx.GetZSet(argString(args[0]))
+		if ok {
+			popped = h.pop(z, int(count))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return newEntriesExpression(popped, true)
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+	"github.com/trinhdaiphuc/go-memcache/sortedset"
+)
+
+type ZRangeHandler struct {
+}
+
+func NewZRangeHandler() Handler {
+	return &ZRangeHandler{}
+}
+
+// Handle implements ZRANGE key start stop [BYSCORE|BYLEX] [REV]
+// [LIMIT offset count] [WITHSCORES]. With REV, start and stop are given from
+// the highest to the lowest element, as in Redis.
+func (h *ZRangeHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 3 {
+		return newWrongNumberOfArgsError(ZRANGE)
+	}
+
+	var byScore, byLex, reverse, withScores, limit bool
+	offset, count := int64(0), int64(-1)
+	for i := 3; i < len(args); i++ {
+		switch strings.ToUpper(argString(args[i])) {
+		case "BYSCORE":
+			byScore = true
+		case "BYLEX":
+			byLex = true
+		case "REV":
+			reverse = true
+		case "WITHSCORES":
+			withScores = true
+		case "LIMIT":
+			if i+2 >= len(args) {
+				return newSyntaxError()
+			}
+			var offsetOK, countOK bool
+			offset, offsetOK = argInt(args[i+1])
+			count, countOK = argInt(args[i+2])
+			if !offsetOK || !countOK {
+				return newNotIntegerError()
+			}
+			limit = true
+			i += 2
+		default:
+			return newSyntaxError()
+		}
+	}
+
+	if byScore && byLex {
+		return newSyntaxError()
+	}
+	if limit && !byScore && !byLex {
+		return resp.NewErrorExpression("ERR syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX")
+	}
+	if withScores && byLex {
+		return resp.NewErrorExpression("ERR syntax error, WITHSCORES not supported in combination with BYLEX")
+	}
+
+	minArg, maxArg := args[1], args[2]
+	if reverse {
+		minArg, maxArg = maxArg, minArg
+	}
+
+	var query func(z *sortedset.SortedSet) []sortedset.Entry
+	switch {
+	case byScore:
+		r, ok := argScoreRange(minArg, maxArg)
+		if !ok {
+			return newScoreRangeError()
+		}
+		query = func(z *sortedset.SortedSet) []sortedset.Entry {
+			return z.RangeByScore(r, reverse, int(offset), int(count))
+		}
+	case byLex:
+		r, ok := argLexRange(minArg, maxArg)
+		if !ok {
+			return newLexRangeError()
+		}
+		query = func(z *sortedset.SortedSet) []sortedset.Entry {
+			return z.RangeByLex(r, reverse, int(offset), int(count))
+		}
+	default:
+		start, startOK := argInt(args[1])
+		stop, stopOK := argInt(args[2])
+		if !startOK || !stopOK {
+			return newNotIntegerError()
+		}
+		query = func(z *sortedset.SortedSet) []sortedset.Entry {
+			return z.RangeByRank(int(start), int(stop), reverse)
+		}
+	}
+
+	var entries []sortedset.Entry
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		z, ok, err := tx.GetZSet(argString(args[0]))
+		if ok && offset >= 0 {
+			entries = query(z)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	return newEntriesExpression(entries, withScores)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import "testing"
+
+func TestZRange(t *testing.T) {
+	runSteps(t, map[string][]step{
+		"by rank": {
+			{"ZADD z 1 a 2 b 3 c 4 d", ":4\r\n"},
+			{"ZRANGE z 0 -1", bulks("a", "b", "c", "d")},
+			{"ZRANGE z 1 2 WITHSCORES", bulks("b", "2", "c", "3")},
+			{"ZRANGE z -2 -1", bulks("c", "d")},
+			{"ZRANGE z 0 1 REV", bulks("d", "c")},
+			{"ZRANGE z 5 10", "*0\r\n"},
+			{"ZRANGE z 2 1", "*0\r\n"},
+		},
+		"by score": {
+			{"ZADD z 1 a 2 b 3 c 4 d", ":4\r\n"},
+			{"ZRANGE z 2 3 BYSCORE", bulks("b", "c")},
+			{"ZRANGE z (2 3 BYSCORE", bulks("c")},
+			{"ZRANGE z (1 (4 BYSCORE WITHSCORES", bulks("b", "2", "c", "3")},
+			{"ZRANGE z -inf +inf BYSCORE", bulks("a", "b", "c", "d")},
+			{"ZRANGE z 3 2 BYSCORE", "*0\r\n"},
+			{"ZRANGE z +inf -inf BYSCORE REV", bulks("d", "c", "b", "a")},
+			{"ZRANGE z (4 2 BYSCORE REV", bulks("c", "b")},
+			{"ZRANGE z -inf +inf BYSCORE LIMIT 1 2", bulks("b", "c")},
+			{"ZRANGE z -inf +inf BYSCORE LIMIT 1 -1", bulks("b", "c", "d")},
+			{"ZRANGE z +inf -inf BYSCORE REV LIMIT 3 5", bulks("a")},
+			{"ZRANGE z -inf +inf BYSCORE LIMIT -1 2", "*0\r\n"},
+		},
+		"by lex": {
+			{"ZADD z 0 a 0 b 0 c 0 d", ":4\r\n"},
+			{"ZRANGE z - + BYLEX", bulks("a", "b", "c", "d")},
+			{"ZRANGE z [b (d BYLEX", bulks("b", "c")},
+			{"ZRANGE z (a [c BYLEX", bulks("b", "c")},
+			{"ZRANGE z + - BYLEX", "*0\r\n"},
+			{"ZRANGE z + - BYLEX REV", bulks("d", "c", "b", "a")},
+			{"ZRANGE z [c - BYLEX REV", bulks("c", "b", "a")},
+			{"ZRANGE z - + BYLEX LIMIT 1 2", bulks("b", "c")},
+		},
+		"missing key": {
+			{"ZRANGE z 0 -1", "*0\r\n"},
+			{"ZRANGE z - + BYLEX", "*0\r\n"},
+		},
+		"invalid arguments": {
+			{"ZRANGE z a 1", "-ERR value is not an integer or out of range\r\n"},
+			{"ZRANGE z a 1 BYSCORE", "-ERR min or max is not a float\r\n"},
+			{"ZRANGE z a c BYLEX", "-ERR min or max not valid string range item\r\n"},
+			{"ZRANGE z 0 1 BYSCORE BYLEX", "-ERR syntax error\r\n"},
+			{"ZRANGE z 0 1 LIMIT 0 1", "-ERR syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX\r\n"},
+			{"ZRANGE z - + BYLEX WITHSCORES", "-ERR syntax error, WITHSCORES not supported in combination with BYLEX\r\n"},
+			{"ZRANGE z 0 1 BYSCORE LIMIT 0", "-ERR syntax error\r\n"},
+			{"ZRANGE z 0 1 BYSCORE LIMIT x 1", "-ERR value is not an integer or out of range\r\n"},
+			{"ZRANGE z 0 1 FOO", "-ERR syntax error\r\n"},
+			{"ZRANGE z 0", "-ERR wrong number of arguments for 'zrange' command\r\n"},
+		},
+		"wrong type": {
+			{"SET z v", "+OK\r\n"},
+			{"ZRANGE z 0 -1", "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"},
+		},
+	})
+}
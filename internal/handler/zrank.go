@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type ZRankHandler struct {
+}
+
+func NewZRankHandler() Handler {
+	return &ZRankHandler{}
+}
+
+// Handle implements ZRANK key member [WITHSCORE].
+func (h *ZRankHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) < 2 || len(args) > 3 {
+		return newWrongNumberOfArgsError(ZRANK)
+	}
+	withScore := len(args) == 3
+	if withScore && !strings.EqualFold(argString(args[2]), "WITHSCORE") {
+		return newSyntaxError()
+	}
+
+	member := argString(args[1])
+	var rank int
+	var score float64
+	var found bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		z, ok, err := tx.GetZSet(argString(args[0]))
+		if ok {
+			rank, found = z.Rank(member, false)
+			score, _ = z.Score(member)
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !found {
+		return resp.NewNullBulkStringExpression()
+	}
+	if withScore {
+		return resp.NewArrayExpression(resp.NewIntegerExpression(rank), resp.NewBulkStringExpression(formatScore(score)))
+	}
+	return resp.NewIntegerExpression(rank)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/trinhdaiphuc/go-memcache/keyspace"
+	"github.com/trinhdaiphuc/go-memcache/resp"
+)
+
+type ZScoreHandler struct {
+}
+
+func NewZScoreHandler() Handler {
+	return &ZScoreHandler{}
+}
+
+func (h *ZScoreHandler) Handle(ctx Context, args []resp.Expression) resp.Expression {
+	if len(args) != 2 {
+		return newWrongNumberOfArgsError(ZSCORE)
+	}
+
+	var score float64
+	var found bool
+	err := ctx.Keyspace.Update(func(tx keyspace.Tx) error {
+		z, ok, err := tx.GetZSet(argString(args[0]))
+		if ok {
+			score, found = z.Score(argString(args[1]))
+		}
+		return err
+	})
+	if err != nil {
+		return newErrorExpression(err)
+	}
+	if !found {
+		return resp.NewNullBulkStringExpression()
+	}
+	return resp.NewBulkStringExpression(formatScore(score))
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/trinhdaiphuc/go-memcache/resp"
+	"github.com/trinhdaiphuc/go-memcache/sortedset"
+)
+
+// formatScore formats a score the way Redis replies with it.
+func formatScore(score float64) string {
+	switch {
+	case math.IsInf(score, 1):
+		return "inf"
+	case math.IsInf(score, -1):
+		return "-inf"
+	}
+
+	if abs := math.Abs(score); abs == 0 || (abs >= 1e-4 && abs < 1e17) {
+		return strconv.FormatFloat(score, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(score, 'g', -1, 64)
+}
+
+func newEntriesExpression(entries []sortedset.Entry, withScores bool) resp.Expression {
+	expressions := make([]resp.Expression, 0, len(entries)*2)
+	for _, entry := range entries {
+		expressions = append(expressions, resp.NewBulkStringExpression(entry.Member))
+		if withScores {
+			expressions = append(expressions, resp.NewBulkStringExpression(formatScore(entry.Score)))
+		}
+	}
+	return resp.NewArrayExpression(expressions...)
+}
+
+func argScore(arg resp.Expression) (float64, bool) {
+	score, ok := argFloat(arg)
+	return score, ok && !math.IsNaN(score)
+}
+
+// argScoreRange parses the min and max of a score range, where a leading "("
+// excludes the bound.
+func argScoreRange(minArg, maxArg resp.Expression) (r sortedset.ScoreRange, ok bool) {
+	bound := func(s string) (float64, bool, bool) {
+		exclusive := strings.HasPrefix(s, "(")
+		score, err := strconv.ParseFloat(strings.TrimPrefix(s, "("), 64)
+		return score, exclusive, err == nil && !math.IsNaN(score)
+	}
+
+	var minOK, maxOK bool
+	r.Min, r.MinExclusive, minOK = bound(argString(minArg))
+	r.Max, r.MaxExclusive, maxOK = bound(argString(maxArg))
+	return r, minOK && maxOK
+}
+
+// argLexRange parses the min and max of a lexicographical range: "[" and "("
+// prefix an inclusive and an exclusive bound, "-" and "+" stand for the lowest
+// and the highest possible string.
+func argLexRange(minArg, maxArg resp.Expression) (r sortedset.LexRange, ok bool) {
+	minString, maxString := argString(minArg), argString(maxArg)
+	for _, s := range []string{minString, maxString} {
+		if s != "-" && s != "+" && !strings.HasPrefix(s, "[") && !strings.HasPrefix(s, "(") {
+			return r, false
+		}
+	}
+
+	// Nothing is above "+" or below "-".
+	if minString == "+" || maxString == "-" {
+		return sortedset.LexRange{Min: "b", Max: "a"}, true
+	}
+
+	r.MinUnbounded = minString == "-"
+	if !r.MinUnbounded {
+		r.Min, r.MinExclusive = minString[1:], minString[0] == '('
+	}
+	r.MaxUnbounded = maxString == "+"
+	if !r.MaxUnbounded {
+		r.Max, r.MaxExclusive = maxString[1:], maxString[0] == '('
+	}
+	return r, true
+}
+
+func newScoreRangeError() resp.Expression {
+	return resp.NewErrorExpression("ERR min or max is not a float")
+}
+
+func newLexRangeError() resp.Expression {
+	return resp.NewErrorExpression("ERR min or max not valid string range item")
+}
@@ -0,0 +1,47 @@
+package keyspace
+
+type CommandKeyspace interface {
+	Execute(k *keyspace)
+}
+
+type updateCommand struct {
+	fn       func(tx Tx) error
+	response chan error
+}
+
+func (c *updateCommand) Execute(k *keyspace) {
+	t := &tx{keyspace: k, touched: make(map[string]struct{})}
+	err := c.run(t)
+	t.dropEmpty()
+	c.response <- err
+	close(c.response)
+}
+
+// run calls the callback, turning a panic into an UpdatePanicError and undoing
+// the keys it wrote instead of letting it take down the command loop, and with
+// it every connection.
+func (c *updateCommand) run(t *tx) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.rollback()
+			err = &UpdatePanicError{Value: r}
+		}
+	}()
+
+	return c.fn(t)
+}
+
+type lenCommand struct {
+	response chan int
+}
+
+func (c *lenCommand) Execute(k *keyspace) {
+	length := 0
+	for _, e := range k.data {
+		if !e.isExpired() {
+			length++
+		}
+	}
+	c.response <- length
+	close(c.response)
+}
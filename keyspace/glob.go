@@ -0,0 +1,95 @@
+package keyspace
+
+// Match reports whether key matches the glob-style pattern used by KEYS and
+// SCAN: "*" matches any sequence of bytes, "?" any single byte, "[...]" one of
+// a set of bytes, or any byte but them if it starts with "^", and "\" escapes
+// the byte following it.
+//
+// Like Redis's stringmatchlen, it only ever backtracks to the last "*" seen,
+// so matching takes O(len(pattern) * len(key)) time at worst.
+func Match(pattern, key string) bool {
+	// starPattern is the pattern after the last "*" and starKey the part of
+	// key that "*" is yet to match, once a match without it fails.
+	starPattern, starKey := "", ""
+	star := false
+	for len(pattern) > 0 || len(key) > 0 {
+		if len(pattern) > 0 {
+			switch pattern[0] {
+			case '*':
+				for len(pattern) > 0 && pattern[0] == '*' {
+					pattern = pattern[1:]
+				}
+				if len(pattern) == 0 {
+					return true
+				}
+				starPattern, starKey, star = pattern, key, true
+				continue
+			case '?':
+				if len(key) > 0 {
+					key = key[1:]
+					pattern = pattern[1:]
+					continue
+				}
+			case '[':
+				if len(key) > 0 {
+					if matched, rest := matchClass(pattern[1:], key[0]); matched {
+						key = key[1:]
+						pattern = rest
+						continue
+					}
+				}
+			default:
+				literal := pattern
+				if literal[0] == '\\' && len(literal) > 1 {
+					literal = literal[1:]
+				}
+				if len(key) > 0 && key[0] == literal[0] {
+					key = key[1:]
+					pattern = literal[1:]
+					continue
+				}
+			}
+		}
+
+		// Let the last "*" match one more byte and retry from there.
+		if !star || len(starKey) == 0 {
+			return false
+		}
+		starKey = starKey[1:]
+		pattern, key = starPattern, starKey
+	}
+	return true
+}
+
+// matchClass reports whether c belongs to the class at the start of pattern,
+// given without its opening "[", and returns the rest of the pattern.
+func matchClass(pattern string, c byte) (bool, string) {
+	negate := len(pattern) > 0 && pattern[0] == '^'
+	if negate {
+		pattern = pattern[1:]
+	}
+
+	matched := false
+	for len(pattern) > 0 && pattern[0] != ']' {
+		switch {
+		case pattern[0] == '\\' && len(pattern) > 1:
+			matched = matched || pattern[1] == c
+			pattern = pattern[2:]
+		case len(pattern) > 2 && pattern[1] == '-' && pattern[2] != ']':
+			lo, hi := pattern[0], pattern[2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			matched = matched || (c >= lo && c <= hi)
+			pattern = pattern[3:]
+		default:
+			matched = matched || pattern[0] == c
+			pattern = pattern[1:]
+		}
+	}
+	if len(pattern) > 0 {
+		// Skip the closing "]". An unclosed class ends with the pattern.
+		pattern = pattern[1:]
+	}
+	return matched != negate, pattern
+}
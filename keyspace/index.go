@@ -0,0 +1,110 @@
+package keyspace
+
+import (
+	"hash/fnv"
+	"math/bits"
+)
+
+// minBuckets is the number of buckets the index never shrinks below.
+const minBuckets = 4
+
+type indexedKey struct {
+	key  string
+	hash uint64
+}
+
+// index is a hash table holding the keys of the keyspace next to its Go map,
+// which cannot be iterated from a cursor. The number of buckets is a power of
+// two, doubled when there are more keys than buckets and halved when they are
+// mostly empty.
+type index struct {
+	buckets [][]indexedKey
+	len     int
+}
+
+func newIndex() *index {
+	return &index{
+		buckets: make([][]indexedKey, minBuckets),
+	}
+}
+
+func (x *index) mask() uint64 {
+	return uint64(len(x.buckets) - 1)
+}
+
+func (x *index) add(key string) {
+	hash := hashKey(key)
+	i := hash & x.mask()
+	x.buckets[i] = append(x.buckets[i], indexedKey{key: key, hash: hash})
+	x.len++
+
+	if x.len > len(x.buckets) {
+		x.resize(2 * len(x.buckets))
+	}
+}
+
+func (x *index) remove(key string) {
+	i := hashKey(key) & x.mask()
+	bucket := x.buckets[i]
+	for j := range bucket {
+		if bucket[j].key != key {
+			continue
+		}
+
+		last := len(bucket) - 1
+		bucket[j] = bucket[last]
+		bucket[last] = indexedKey{}
+		x.buckets[i] = bucket[:last]
+		x.len--
+		break
+	}
+
+	if len(x.buckets) > minBuckets && x.len < len(x.buckets)/8 {
+		x.resize(max(minBuckets, 1<<bits.Len(uint(x.len))))
+	}
+}
+
+func (x *index) resize(size int) {
+	buckets := make([][]indexedKey, size)
+	mask := uint64(size - 1)
+	for _, bucket := range x.buckets {
+		for _, k := range bucket {
+			buckets[k.hash&mask] = append(buckets[k.hash&mask], k)
+		}
+	}
+	x.buckets = buckets
+}
+
+// scan calls fn with the keys of the buckets from cursor on, until it has
+// been called count times or 10 times count buckets have been visited, and
+// returns the cursor to continue from, which is zero once every bucket has
+// been visited.
+//
+// As in Redis, buckets are visited in the order of their index with its bits
+// reversed. Growing or shrinking the table by powers of two keeps the buckets
+// already visited before the cursor in that order, so a key present during a
+// whole iteration is returned at least once whatever the resizes in between.
+func (x *index) scan(cursor uint64, count int, fn func(key string)) uint64 {
+	mask := x.mask()
+	called := 0
+	for visited := 0; visited < 10*count; visited++ {
+		for _, k := range x.buckets[cursor&mask] {
+			fn(k.key)
+			called++
+		}
+
+		// Increment the reversed bits of the cursor that address a bucket.
+		cursor |= ^mask
+		cursor = bits.Reverse64(bits.Reverse64(cursor) + 1)
+		if cursor == 0 || called >= count {
+			break
+		}
+	}
+	return cursor
+}
+
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
@@ -0,0 +1,163 @@
+package keyspace
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/hashmap"
+	"github.com/trinhdaiphuc/go-memcache/list"
+	"github.com/trinhdaiphuc/go-memcache/set"
+	"github.com/trinhdaiphuc/go-memcache/sortedset"
+)
+
+// ErrWrongType is returned when a key is accessed as a type it does not hold.
+var ErrWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+// UpdatePanicError is returned by Update when its callback panics. The panic is
+// recovered so that the keyspace keeps serving other callers.
+type UpdatePanicError struct {
+	Value any
+}
+
+func (e *UpdatePanicError) Error() string {
+	return fmt.Sprintf("keyspace: update panicked: %v", e.Value)
+}
+
+// Type is the type of the value held by a key, named as the TYPE command
+// reports it.
+type Type string
+
+const (
+	TypeNone   Type = "none"
+	TypeString Type = "string"
+	TypeHash   Type = "hash"
+	TypeList   Type = "list"
+	TypeSet    Type = "set"
+	TypeZSet   Type = "zset"
+)
+
+// Keyspace maps every key to a single typed value, which expires as a whole.
+// Values are only reachable through Update, which serializes every access, so
+// the hashes, lists, sets and sorted sets it holds need no locking of their
+// own.
+type Keyspace interface {
+	// Update runs fn inside the keyspace's command loop and returns its error.
+	// Changes are applied as fn makes them, whatever it returns. If fn panics,
+	// Update returns an UpdatePanicError instead and restores the keys fn
+	// created, replaced, renamed, deleted or changed the TTL of; changes made
+	// in place to a value returned by a getter are kept. fn must not use tx
+	// after returning, nor call methods of the keyspace.
+	Update(fn func(tx Tx) error) error
+	Len() int
+}
+
+type keyspace struct {
+	data map[string]*entry
+	// index holds the keys of data in an order SCAN can resume from.
+	index   *index
+	command chan CommandKeyspace
+}
+
+func NewKeyspace() Keyspace {
+	k := &keyspace{
+		data:    make(map[string]*entry),
+		index:   newIndex(),
+		command: make(chan CommandKeyspace),
+	}
+
+	go k.executeCommands()
+
+	return k
+}
+
+func (k *keyspace) Update(fn func(tx Tx) error) error {
+	response := make(chan error)
+	k.command <- &updateCommand{fn: fn, response: response}
+	return <-response
+}
+
+func (k *keyspace) Len() int {
+	length := make(chan int)
+	k.command <- &lenCommand{response: length}
+	return <-length
+}
+
+func (k *keyspace) executeCommands() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case cmd := <-k.command:
+			cmd.Execute(k)
+		case <-ticker.C:
+			k.clearExpiredData()
+		}
+	}
+}
+
+// clearExpiredData removes the keys that expired without being accessed
+// since. Accessed keys are removed as soon as they are found expired.
+func (k *keyspace) clearExpiredData() {
+	for key, e := range k.data {
+		if e.isExpired() {
+			k.delete(key)
+		}
+	}
+}
+
+// lookup returns the live entry at key, removing it if it has expired.
+func (k *keyspace) lookup(key string) (*entry, bool) {
+	e, ok := k.data[key]
+	if !ok {
+		return nil, false
+	}
+	if e.isExpired() {
+		k.delete(key)
+		return nil, false
+	}
+	return e, true
+}
+
+func (k *keyspace) delete(key string) bool {
+	if _, ok := k.data[key]; !ok {
+		return false
+	}
+
+	delete(k.data, key)
+	k.index.remove(key)
+	return true
+}
+
+// set stores e at key, which must not exist.
+func (k *keyspace) set(key string, e *entry) {
+	k.data[key] = e
+	k.index.add(key)
+}
+
+type entry struct {
+	typ       Type
+	value     any
+	expiresAt time.Time
+}
+
+func (e *entry) isExpired() bool {
+	return !e.expiresAt.IsZero() && time.Now().After(e.expiresAt)
+}
+
+// isEmpty reports whether e holds a collection with no element left, which
+// the keyspace removes as Redis does.
+func (e *entry) isEmpty() bool {
+	switch v := e.value.(type) {
+	case *hashmap.Hash[string, string]:
+		return v.Len() == 0
+	case *list.List:
+		return v.Len() == 0
+	case *set.Set[string]:
+		return v.Card() == 0
+	case *sortedset.SortedSet:
+		return v.Card() == 0
+	}
+	return false
+}
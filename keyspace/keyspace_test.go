@@ -0,0 +1,319 @@
+package keyspace
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyspaceTypes(t *testing.T) {
+	k := NewKeyspace()
+
+	_ = k.Update(func(tx Tx) error {
+		tx.SetString("name", "user1")
+		l, err := tx.GetOrCreateList("queue")
+		assert.NoError(t, err)
+		l.PushBack("job")
+		h, err := tx.GetOrCreateHash("user:1")
+		assert.NoError(t, err)
+		h.Set("name", "user1")
+		return nil
+	})
+
+	_ = k.Update(func(tx Tx) error {
+		assert.Equal(t, tx.Type("name"), TypeString)
+		assert.Equal(t, tx.Type("queue"), TypeList)
+		assert.Equal(t, tx.Type("user:1"), TypeHash)
+		assert.Equal(t, tx.Type("missing"), TypeNone)
+
+		_, _, err := tx.GetList("name")
+		assert.ErrorIs(t, err, ErrWrongType)
+		_, err = tx.GetOrCreateZSet("queue")
+		assert.ErrorIs(t, err, ErrWrongType)
+		value, ok, err := tx.GetString("name")
+		assert.NoError(t, err)
+		assert.Truef(t, ok && value == "user1", "tx.GetString(name) = %s, %t; want user1, true", value, ok)
+
+		// The bytes of a string are modified in place, unlike its copies.
+		b, _, _ := tx.GetBytes("name")
+		b[4] = '2'
+		value, _, _ = tx.GetString("name")
+		assert.Equalf(t, value, "user2", "tx.GetString(name) = %s; want user2", value)
+		b[4] = '3'
+		assert.Equalf(t, value, "user2", "value = %s; want user2", value)
+
+		// SET replaces a value of any type.
+		tx.SetString("queue", "text")
+		assert.Equal(t, tx.Type("queue"), TypeString)
+		return nil
+	})
+	assert.Equalf(t, k.Len(), 3, "k.Len() = %d; want 3", k.Len())
+}
+
+func TestKeyspaceDropsEmptyCollections(t *testing.T) {
+	k := NewKeyspace()
+
+	_ = k.Update(func(tx Tx) error {
+		s, _ := tx.GetOrCreateSet("tags")
+		s.Add("go")
+		_, _ = tx.GetOrCreateZSet("board")
+		return nil
+	})
+	_ = k.Update(func(tx Tx) error {
+		assert.Truef(t, tx.Exists("tags"), "tx.Exists(tags) = false; want true")
+		assert.Falsef(t, tx.Exists("board"), "tx.Exists(board) = true; want false")
+
+		s, _, _ := tx.GetSet("tags")
+		s.Remove("go")
+		return nil
+	})
+	assert.Zerof(t, k.Len(), "k.Len() = %d; want 0", k.Len())
+}
+
+func TestKeyspaceRenameAndExpire(t *testing.T) {
+	k := NewKeyspace()
+
+	_ = k.Update(func(tx Tx) error {
+		tx.SetString("a", "1")
+		tx.SetString("b", "2")
+		assert.Truef(t, tx.Expire("a", 50*time.Millisecond), "tx.Expire(a) = false; want true")
+		assert.Falsef(t, tx.Expire("missing", time.Second), "tx.Expire(missing) = true; want false")
+
+		assert.Truef(t, tx.Rename("a", "b"), "tx.Rename(a, b) = false; want true")
+		assert.Falsef(t, tx.Rename("a", "c"), "tx.Rename(a, c) = true; want false")
+		value, _, _ := tx.GetString("b")
+		assert.Equalf(t, value, "1", "tx.GetString(b) = %s; want 1", value)
+
+		ttl, ok := tx.TTL("b")
+		assert.Truef(t, ok && ttl > 0 && ttl <= 50*time.Millisecond, "tx.TTL(b) = %s, %t", ttl, ok)
+		at, _ := tx.ExpireTime("b")
+		assert.Truef(t, time.Until(at) > 0, "tx.ExpireTime(b) = %s; want a time in the future", at)
+		return nil
+	})
+
+	time.Sleep(100 * time.Millisecond)
+	_ = k.Update(func(tx Tx) error {
+		assert.Falsef(t, tx.Exists("b"), "tx.Exists(b) = true after its TTL elapsed")
+		assert.Empty(t, tx.Keys())
+		return nil
+	})
+}
+
+func TestKeyspaceUpdatePanic(t *testing.T) {
+	k := NewKeyspace()
+
+	_ = k.Update(func(tx Tx) error {
+		tx.SetString("a", "1")
+		tx.SetString("b", "2")
+		tx.Expire("b", time.Minute)
+		l, _ := tx.GetOrCreateList("queue")
+		l.PushBack("job")
+		return nil
+	})
+
+	err := k.Update(func(tx Tx) error {
+		tx.Rename("a", "b")
+		tx.Expire("b", 0)
+		tx.Delete("queue")
+		tx.SetString("c", "3")
+		_, _ = tx.GetOrCreateSet("tags")
+		panic("boom")
+	})
+	var panicErr *UpdatePanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equalf(t, panicErr.Value, "boom", "panicErr.Value = %v; want boom", panicErr.Value)
+
+	_ = k.Update(func(tx Tx) error {
+		a, _, _ := tx.GetString("a")
+		b, _, _ := tx.GetString("b")
+		assert.Truef(t, a == "1" && b == "2", "tx.GetString(a), tx.GetString(b) = %s, %s; want 1, 2", a, b)
+		ttl, _ := tx.TTL("b")
+		assert.Truef(t, ttl > 0 && ttl <= time.Minute, "tx.TTL(b) = %s; want (0, 1m]", ttl)
+		assert.Equal(t, tx.Type("queue"), TypeList)
+		assert.Falsef(t, tx.Exists("c"), "tx.Exists(c) = true; want false")
+		assert.Falsef(t, tx.Exists("tags"), "tx.Exists(tags) = true; want false")
+		return nil
+	})
+	assert.Equalf(t, k.Len(), 3, "k.Len() = %d; want 3", k.Len())
+}
+
+func TestKeyspaceScan(t *testing.T) {
+	k := NewKeyspace()
+
+	_ = k.Update(func(tx Tx) error {
+		for i := 0; i < 100; i++ {
+			tx.SetString(strconv.Itoa(i), "value")
+		}
+		return nil
+	})
+
+	seen := make(map[string]int)
+	_ = k.Update(func(tx Tx) error {
+		cursor := uint64(0)
+		for {
+			keys, next := tx.Scan(cursor, 10)
+			assert.NotEmpty(t, keys)
+			for _, key := range keys {
+				seen[key]++
+			}
+			// Keys added or removed while scanning do not disturb the
+			// iteration.
+			tx.Delete(keys[0])
+			tx.SetString("new-"+keys[0], "value")
+
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	})
+	for i := 1; i < 100; i++ {
+		count := seen[strconv.Itoa(i)]
+		assert.Truef(t, count >= 1, "key %d returned %d times; want at least once", i, count)
+	}
+}
+
+func TestKeyspaceScanWhileResizing(t *testing.T) {
+	k := NewKeyspace()
+
+	_ = k.Update(func(tx Tx) error {
+		for i := 0; i < 100; i++ {
+			tx.SetString(strconv.Itoa(i), "value")
+		}
+		return nil
+	})
+
+	seen := make(map[string]int)
+	calls := 0
+	_ = k.Update(func(tx Tx) error {
+		cursor := uint64(0)
+		for {
+			keys, next := tx.Scan(cursor, 5)
+			calls++
+			for _, key := range keys {
+				seen[key]++
+			}
+
+			// Grow the table while scanning, then shrink it back.
+			switch calls {
+			case 3:
+				for i := 0; i < 1000; i++ {
+					tx.SetString("grow-"+strconv.Itoa(i), "value")
+				}
+			case 6:
+				for i := 0; i < 1000; i++ {
+					tx.Delete("grow-" + strconv.Itoa(i))
+				}
+			}
+
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	})
+	for i := 0; i < 100; i++ {
+		count := seen[strconv.Itoa(i)]
+		assert.Truef(t, count >= 1, "key %d returned %d times; want at least once", i, count)
+	}
+}
+
+func TestIndexScanCost(t *testing.T) {
+	x := newIndex()
+	for i := 0; i < 100000; i++ {
+		x.add(strconv.Itoa(i))
+	}
+
+	calls, total := 0, 0
+	cursor := uint64(0)
+	for {
+		visited := 0
+		cursor = x.scan(cursor, 10, func(key string) {
+			visited++
+		})
+		assert.LessOrEqualf(t, visited, 100, "scan(10) visited %d keys; want about 10", visited)
+		calls++
+		total += visited
+		if cursor == 0 {
+			break
+		}
+	}
+	assert.Equalf(t, total, 100000, "scan returned %d keys; want 100000", total)
+	assert.Lessf(t, calls, 100000/5, "scan took %d calls; want fewer than 20000", calls)
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"*", "", true},
+		{"*", "user:1", true},
+		{"user:*", "user:1", true},
+		{"user:*", "users", false},
+		{"h?llo", "hello", true},
+		{"h?llo", "hllo", false},
+		{"h*llo", "heeeello", true},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-b]llo", "hbllo", true},
+		{"h[b-a]llo", "hbllo", true},
+		{"h[a-b]llo", "hcllo", false},
+		{`h\*llo`, "h*llo", true},
+		{`h\*llo`, "hello", false},
+		{"a*b*c", "aXbYc", true},
+		{"a*b*c", "aXbY", false},
+		{"a*b*c", "abcbc", true},
+		{"*c", "abcbd", false},
+		{"**a", "ba", true},
+		{"a*", "", false},
+		{"*?", "", false},
+		{"*[xy]z", "xyxz", true},
+		{`a\`, `a\`, true},
+	}
+	for _, tt := range tests {
+		got := Match(tt.pattern, tt.key)
+		assert.Equalf(t, got, tt.want, "Match(%q, %q) = %t; want %t", tt.pattern, tt.key, got, tt.want)
+	}
+
+	// A pattern with many stars must not backtrack exponentially.
+	pattern := strings.Repeat("a*", 30) + "b"
+	key := strings.Repeat("a", 100)
+	start := time.Now()
+	assert.Falsef(t, Match(pattern, key), "Match(%q, %q) = true; want false", pattern, key)
+	assert.Lessf(t, time.Since(start), time.Second, "Match took %s", time.Since(start))
+}
+
+func TestConcurrencyKeyspace(t *testing.T) {
+	k := NewKeyspace()
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = k.Update(func(tx Tx) error {
+				l, err := tx.GetOrCreateList("queue")
+				if err != nil {
+					return err
+				}
+				l.PushBack("job")
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	_ = k.Update(func(tx Tx) error {
+		l, _, _ := tx.GetList("queue")
+		assert.Equalf(t, l.Len(), 100, "l.Len() = %d; want 100", l.Len())
+		return nil
+	})
+}
@@ -0,0 +1,293 @@
+package keyspace
+
+import (
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/hashmap"
+	"github.com/trinhdaiphuc/go-memcache/list"
+	"github.com/trinhdaiphuc/go-memcache/set"
+	"github.com/trinhdaiphuc/go-memcache/sortedset"
+)
+
+// Tx is the view of a Keyspace passed to an Update callback.
+//
+// The typed getters return the value at key and whether it exists, or
+// ErrWrongType if it holds another type. The values they return may be
+// modified in place until the callback returns; collections left empty are
+// then removed. The GetOrCreate variants store a new empty value when the key
+// does not exist.
+type Tx interface {
+	Type(key string) Type
+	Exists(key string) bool
+	Delete(key string) bool
+	// Rename moves the value and TTL of src to dst, replacing any value at
+	// dst, and reports whether src existed.
+	Rename(src, dst string) bool
+	Keys() []string
+	// Scan returns about count keys from cursor on and the cursor to continue
+	// from, which is zero once every key has been returned. As in Redis, a
+	// key existing during the whole iteration is returned at least once,
+	// however many keys are added or removed in between, and a call only
+	// costs about count keys. count must be positive.
+	Scan(cursor uint64, count int) ([]string, uint64)
+
+	// Expire sets the time to live of key and reports whether it exists. A
+	// non-positive ttl removes any expiry.
+	Expire(key string, ttl time.Duration) bool
+	// ExpireAt makes key expire at at, or never if at is zero, and reports
+	// whether it exists.
+	ExpireAt(key string, at time.Time) bool
+	// TTL returns the time left before key expires, or zero if it never
+	// does, and whether it exists.
+	TTL(key string) (time.Duration, bool)
+	// ExpireTime returns the time key expires at, or zero if it never does,
+	// and whether it exists.
+	ExpireTime(key string) (time.Time, bool)
+
+	// GetString returns a copy of the string at key.
+	GetString(key string) (string, bool, error)
+	// SetString stores value at key, replacing any value of any type along
+	// with its TTL.
+	SetString(key, value string)
+	// GetBytes returns the string at key without copying it, so that the
+	// commands modifying a string in place, such as SETBIT, do not cost its
+	// whole length.
+	GetBytes(key string) ([]byte, bool, error)
+	// SetBytes is SetString without copying value, which the keyspace owns
+	// from then on.
+	SetBytes(key string, value []byte)
+
+	GetHash(key string) (*hashmap.Hash[string, string], bool, error)
+	GetOrCreateHash(key string) (*hashmap.Hash[string, string], error)
+	GetList(key string) (*list.List, bool, error)
+	GetOrCreateList(key string) (*list.List, error)
+	GetSet(key string) (*set.Set[string], bool, error)
+	GetOrCreateSet(key string) (*set.Set[string], error)
+	GetZSet(key string) (*sortedset.SortedSet, bool, error)
+	GetOrCreateZSet(key string) (*sortedset.SortedSet, error)
+}
+
+type tx struct {
+	keyspace *keyspace
+	// touched holds the keys of the collections handed out, which may have
+	// been emptied.
+	touched map[string]struct{}
+	// saved holds the entries of the keys written, as they were before the
+	// first write, for rollback.
+	saved map[string]savedEntry
+}
+
+type savedEntry struct {
+	entry     *entry
+	expiresAt time.Time
+}
+
+// save records the entry at key, if any, before it is first written.
+func (t *tx) save(key string) {
+	if _, ok := t.saved[key]; ok {
+		return
+	}
+	if t.saved == nil {
+		t.saved = make(map[string]savedEntry)
+	}
+
+	var saved savedEntry
+	if e, ok := t.keyspace.data[key]; ok {
+		saved = savedEntry{entry: e, expiresAt: e.expiresAt}
+	}
+	t.saved[key] = saved
+}
+
+// rollback puts back the entries of the keys written as they were saved.
+func (t *tx) rollback() {
+	for key, saved := range t.saved {
+		t.keyspace.delete(key)
+		if saved.entry != nil {
+			saved.entry.expiresAt = saved.expiresAt
+			t.keyspace.set(key, saved.entry)
+		}
+	}
+}
+
+func (t *tx) Type(key string) Type {
+	e, ok := t.keyspace.lookup(key)
+	if !ok {
+		return TypeNone
+	}
+	return e.typ
+}
+
+func (t *tx) Exists(key string) bool {
+	_, ok := t.keyspace.lookup(key)
+	return ok
+}
+
+func (t *tx) Delete(key string) bool {
+	if _, ok := t.keyspace.lookup(key); !ok {
+		return false
+	}
+	t.save(key)
+	return t.keyspace.delete(key)
+}
+
+func (t *tx) Rename(src, dst string) bool {
+	e, ok := t.keyspace.lookup(src)
+	if !ok {
+		return false
+	}
+	if src == dst {
+		return true
+	}
+
+	t.save(src)
+	t.save(dst)
+	t.keyspace.delete(dst)
+	t.keyspace.delete(src)
+	t.keyspace.set(dst, e)
+	return true
+}
+
+func (t *tx) Keys() []string {
+	keys := make([]string, 0, len(t.keyspace.data))
+	for key, e := range t.keyspace.data {
+		if !e.isExpired() {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (t *tx) Scan(cursor uint64, count int) ([]string, uint64) {
+	keys := make([]string, 0, count)
+	next := t.keyspace.index.scan(cursor, count, func(key string) {
+		if !t.keyspace.data[key].isExpired() {
+			keys = append(keys, key)
+		}
+	})
+	return keys, next
+}
+
+func (t *tx) Expire(key string, ttl time.Duration) bool {
+	var at time.Time
+	if ttl > 0 {
+		at = time.Now().Add(ttl)
+	}
+	return t.ExpireAt(key, at)
+}
+
+func (t *tx) ExpireAt(key string, at time.Time) bool {
+	e, ok := t.keyspace.lookup(key)
+	if !ok {
+		return false
+	}
+	t.save(key)
+	e.expiresAt = at
+	return true
+}
+
+func (t *tx) TTL(key string) (time.Duration, bool) {
+	e, ok := t.keyspace.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	if e.expiresAt.IsZero() {
+		return 0, true
+	}
+	return max(time.Until(e.expiresAt), 1), true
+}
+
+func (t *tx) ExpireTime(key string) (time.Time, bool) {
+	e, ok := t.keyspace.lookup(key)
+	if !ok {
+		return time.Time{}, false
+	}
+	return e.expiresAt, true
+}
+
+func (t *tx) GetString(key string) (string, bool, error) {
+	value, ok, err := t.GetBytes(key)
+	return string(value), ok, err
+}
+
+func (t *tx) SetString(key, value string) {
+	t.SetBytes(key, []byte(value))
+}
+
+func (t *tx) GetBytes(key string) ([]byte, bool, error) {
+	return lookupAs[[]byte](t, key, TypeString)
+}
+
+func (t *tx) SetBytes(key string, value []byte) {
+	t.save(key)
+	t.keyspace.delete(key)
+	t.keyspace.set(key, &entry{typ: TypeString, value: value})
+}
+
+func (t *tx) GetHash(key string) (*hashmap.Hash[string, string], bool, error) {
+	return lookupAs[*hashmap.Hash[string, string]](t, key, TypeHash)
+}
+
+func (t *tx) GetOrCreateHash(key string) (*hashmap.Hash[string, string], error) {
+	return getOrCreate(t, key, TypeHash, hashmap.NewHash[string, string])
+}
+
+func (t *tx) GetList(key string) (*list.List, bool, error) {
+	return lookupAs[*list.List](t, key, TypeList)
+}
+
+func (t *tx) GetOrCreateList(key string) (*list.List, error) {
+	return getOrCreate(t, key, TypeList, list.New)
+}
+
+func (t *tx) GetSet(key string) (*set.Set[string], bool, error) {
+	return lookupAs[*set.Set[string]](t, key, TypeSet)
+}
+
+func (t *tx) GetOrCreateSet(key string) (*set.Set[string], error) {
+	return getOrCreate(t, key, TypeSet, func() *set.Set[string] {
+		return set.NewSet[string]()
+	})
+}
+
+func (t *tx) GetZSet(key string) (*sortedset.SortedSet, bool, error) {
+	return lookupAs[*sortedset.SortedSet](t, key, TypeZSet)
+}
+
+func (t *tx) GetOrCreateZSet(key string) (*sortedset.SortedSet, error) {
+	return getOrCreate(t, key, TypeZSet, sortedset.New)
+}
+
+func lookupAs[T any](t *tx, key string, typ Type) (value T, ok bool, err error) {
+	e, ok := t.keyspace.lookup(key)
+	if !ok {
+		return value, false, nil
+	}
+	if e.typ != typ {
+		return value, false, ErrWrongType
+	}
+
+	t.touched[key] = struct{}{}
+	return e.value.(T), true, nil
+}
+
+func getOrCreate[T any](t *tx, key string, typ Type, create func() T) (T, error) {
+	value, ok, err := lookupAs[T](t, key, typ)
+	if err != nil || ok {
+		return value, err
+	}
+
+	value = create()
+	t.save(key)
+	t.keyspace.set(key, &entry{typ: typ, value: value})
+	t.touched[key] = struct{}{}
+	return value, nil
+}
+
+// dropEmpty removes the collections that the callback left empty.
+func (t *tx) dropEmpty() {
+	for key := range t.touched {
+		if e, ok := t.keyspace.data[key]; ok && e.isEmpty() {
+			t.keyspace.delete(key)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package list
+
+import "slices"
+
+// chunkSize is the number of values a single node of the list holds at most.
+const chunkSize = 128
+
+type node struct {
+	entries    []string
+	prev, next *node
+}
+
+// List is a deque of strings stored as a doubly linked list of small slices,
+// like a Redis quicklist: values are packed together instead of costing a
+// list element each, while pushes and pops at both ends stay cheap. It is not
+// safe for concurrent use.
+type List struct {
+	head, tail *node
+	length     int
+}
+
+func New() *List {
+	return &List{}
+}
+
+func (l *List) Len() int {
+	return l.length
+}
+
+// linkBefore links n in front of mark, or as the only node if mark is nil.
+func (l *List) linkBefore(mark, n *node) {
+	if mark == nil {
+		l.head, l.tail = n, n
+		return
+	}
+
+	n.next, n.prev = mark, mark.prev
+	if mark.prev != nil {
+		mark.prev.next = n
+	} else {
+		l.head = n
+	}
+	mark.prev = n
+}
+
+// linkAfter links n behind mark, or as the only node if mark is nil.
+func (l *List) linkAfter(mark, n *node) {
+	if mark == nil {
+		l.head, l.tail = n, n
+		return
+	}
+
+	n.prev, n.next = mark, mark.next
+	if mark.next != nil {
+		mark.next.prev = n
+	} else {
+		l.tail = n
+	}
+	mark.next = n
+}
+
+func (l *List) unlink(n *node) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		l.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		l.tail = n.prev
+	}
+	n.prev, n.next = nil, nil
+}
+
+// PushFront inserts values at the head of the list one after the other, so
+// that the last one ends up first, and returns the length of the list.
+func (l *List) PushFront(values ...string) int {
+	for _, value := range values {
+		if l.head == nil || len(l.head.entries) >= chunkSize {
+			l.linkBefore(l.head, &node{})
+		}
+		l.head.entries = slices.Insert(l.head.entries, 0, value)
+		l.length++
+	}
+	return l.length
+}
+
+// PushBack appends values at the tail of the list and returns its length.
+func (l *List) PushBack(values ...string) int {
+	for _, value := range values {
+		if l.tail == nil || len(l.tail.entries) >= chunkSize {
+			l.linkAfter(l.tail, &node{})
+		}
+		l.tail.entries = append(l.tail.entries, value)
+		l.length++
+	}
+	return l.length
+}
+
+// PopFront removes and returns up to count values from the head of the list.
+func (l *List) PopFront(count int) []string {
+	values := make([]string, 0, max(min(count, l.length), 0))
+	for l.head != nil && len(values) < count {
+		n := min(count-len(values), len(l.head.entries))
+		values = append(values, l.head.entries[:n]...)
+		l.head.entries = slices.Delete(l.head.entries, 0, n)
+		l.length -= n
+		if len(l.head.entries) == 0 {
+			l.unlink(l.head)
+		}
+	}
+	return values
+}
+
+// PopBack removes and returns up to count values from the tail of the list,
+// the last one first.
+func (l *List) PopBack(count int) []string {
+	values := make([]string, 0, max(min(count, l.length), 0))
+	for l.tail != nil && len(values) < count {
+		entries := l.tail.entries
+		n := min(count-len(values), len(entries))
+		for i := len(entries) - 1; i >= len(entries)-n; i-- {
+			values = append(values, entries[i])
+		}
+		l.tail.entries = slices.Delete(entries, len(entries)-n, len(entries))
+		l.length -= n
+		if len(l.tail.entries) == 0 {
+			l.unlink(l.tail)
+		}
+	}
+	return values
+}
+
+// index turns a possibly negative index into a position from the head, and
+// reports whether it is in the list.
+func (l *List) index(index int) (int, bool) {
+	if index < 0 {
+		index += l.length
+	}
+	return index, index >= 0 && index < l.length
+}
+
+// locate returns the node holding the value at position index and the offset
+// of the value in it. index must be in the list.
+func (l *List) locate(index int) (*node, int) {
+	if index < l.length/2 {
+		for n := l.head; ; n = n.next {
+			if index < len(n.entries) {
+				return n, index
+			}
+			index -= len(n.entries)
+		}
+	}
+
+	index = l.length - 1 - index
+	for n := l.tail; ; n = n.prev {
+		if index < len(n.entries) {
+			return n, len(n.entries) - 1 - index
+		}
+		index -= len(n.entries)
+	}
+}
+
+// Index returns the value at index. Negative indexes count from the tail.
+func (l *List) Index(index int) (string, bool) {
+	index, ok := l.index(index)
+	if !ok {
+		return "", false
+	}
+
+	n, i := l.locate(index)
+	return n.entries[i], true
+}
+
+// Set replaces the value at index and reports whether index is in the list.
+func (l *List) Set(index int, value string) bool {
+	index, ok := l.index(index)
+	if !ok {
+		return false
+	}
+
+	n, i := l.locate(index)
+	n.entries[i] = value
+	return true
+}
+
+// bounds clamps the inclusive range from start to stop, where negative
+// indexes count from the tail, to the list, as LRANGE and LTRIM do.
+func (l *List) bounds(start, stop int) (int, int, bool) {
+	if start < 0 {
+		start += l.length
+	}
+	if stop < 0 {
+		stop += l.length
+	}
+	start = max(start, 0)
+	if start > stop || start >= l.length {
+		return 0, 0, false
+	}
+	return start, min(stop, l.length-1), true
+}
+
+// Range returns the values from start to stop included.
+func (l *List) Range(start, stop int) []string {
+	start, stop, ok := l.bounds(start, stop)
+	if !ok {
+		return nil
+	}
+
+	values := make([]string, 0, stop-start+1)
+	n, i := l.locate(start)
+	for ; n != nil && len(values) < cap(values); n, i = n.next, 0 {
+		values = append(values, n.entries[i:min(len(n.entries), i+cap(values)-len(values))]...)
+	}
+	return values
+}
+
+// Insert inserts value before or after the first occurrence of pivot. It
+// returns the length of the list, or -1 if pivot was not found.
+func (l *List) Insert(pivot, value string, before bool) int {
+	for n := l.head; n != nil; n = n.next {
+		i := slices.Index(n.entries, pivot)
+		if i < 0 {
+			continue
+		}
+
+		if !before {
+			i++
+		}
+		l.insertAt(n, i, value)
+		return l.length
+	}
+	return -1
+}
+
+// insertAt inserts value at offset i of n, splitting n in two if it is full.
+func (l *List) insertAt(n *node, i int, value string) {
+	if len(n.entries) >= chunkSize {
+		half := len(n.entries) / 2
+		split := &node{entries: slices.Clone(n.entries[half:])}
+		n.entries = slices.Delete(n.entries, half, len(n.entries))
+		l.linkAfter(n, split)
+		if i > half {
+			n, i = split, i-half
+		}
+	}
+
+	n.entries = slices.Insert(n.entries, i, value)
+	l.length++
+}
+
+// Remove removes the values equal to value and returns how many it removed.
+// A positive count removes at most count of them from the head, a negative
+// one from the tail, and zero removes all of them, as LREM does.
+func (l *List) Remove(count int, value string) int {
+	limit := max(count, -count)
+	removed := 0
+	done := func() bool {
+		return limit > 0 && removed == limit
+	}
+
+	if count >= 0 {
+		for n := l.head; n != nil && !done(); {
+			next := n.next
+			for i := 0; i < len(n.entries) && !done(); {
+				if n.entries[i] != value {
+					i++
+					continue
+				}
+				n.entries = slices.Delete(n.entries, i, i+1)
+				removed++
+			}
+			if len(n.entries) == 0 {
+				l.unlink(n)
+			}
+			n = next
+		}
+	} else {
+		for n := l.tail; n != nil && !done(); {
+			prev := n.prev
+			for i := len(n.entries) - 1; i >= 0 && !done(); i-- {
+				if n.entries[i] == value {
+					n.entries = slices.Delete(n.entries, i, i+1)
+					removed++
+				}
+			}
+			if len(n.entries) == 0 {
+				l.unlink(n)
+			}
+			n = prev
+		}
+	}
+
+	l.length -= removed
+	return removed
+}
+
+// Trim keeps only the values from start to stop included.
+func (l *List) Trim(start, stop int) {
+	start, stop, ok := l.bounds(start, stop)
+	if !ok {
+		l.head, l.tail, l.length = nil, nil, 0
+		return
+	}
+
+	l.PopBack(l.length - 1 - stop)
+	l.PopFront(start)
+}
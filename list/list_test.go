@@ -0,0 +1,101 @@
+package list
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	l := New()
+
+	length := l.PushBack("b", "c")
+	assert.Equalf(t, length, 2, "l.PushBack(b, c) = %d; want 2", length)
+	length = l.PushFront("a", "z")
+	assert.Equalf(t, length, 4, "l.PushFront(a, z) = %d; want 4", length)
+	assert.Equal(t, l.Range(0, -1), []string{"z", "a", "b", "c"})
+
+	value, ok := l.Index(-1)
+	assert.Truef(t, ok && value == "c", "l.Index(-1) = %s, %t; want c, true", value, ok)
+	_, ok = l.Index(4)
+	assert.Falsef(t, ok, "l.Index(4) found a value")
+	assert.Truef(t, l.Set(1, "A"), "l.Set(1, A) = false; want true")
+
+	length = l.Insert("b", "x", true)
+	assert.Equalf(t, length, 5, "l.Insert(b, x, before) = %d; want 5", length)
+	length = l.Insert("missing", "x", true)
+	assert.Equalf(t, length, -1, "l.Insert(missing, x) = %d; want -1", length)
+	assert.Equal(t, l.Range(-3, 10), []string{"x", "b", "c"})
+
+	assert.Equal(t, l.PopFront(2), []string{"z", "A"})
+	assert.Equal(t, l.PopBack(5), []string{"c", "b", "x"})
+	assert.Zerof(t, l.Len(), "l.Len() = %d; want 0", l.Len())
+	assert.Empty(t, l.Range(0, -1))
+}
+
+func TestListRemoveAndTrim(t *testing.T) {
+	l := New()
+	l.PushBack("a", "b", "a", "c", "a", "b")
+
+	removed := l.Remove(-2, "a")
+	assert.Equalf(t, removed, 2, "l.Remove(-2, a) = %d; want 2", removed)
+	assert.Equal(t, l.Range(0, -1), []string{"a", "b", "c", "b"})
+	removed = l.Remove(0, "b")
+	assert.Equalf(t, removed, 2, "l.Remove(0, b) = %d; want 2", removed)
+	assert.Equal(t, l.Range(0, -1), []string{"a", "c"})
+
+	l.PushBack("d", "e", "f")
+	l.Trim(1, -2)
+	assert.Equal(t, l.Range(0, -1), []string{"c", "d", "e"})
+	l.Trim(5, 10)
+	assert.Zerof(t, l.Len(), "l.Len() = %d; want 0", l.Len())
+}
+
+// TestListChunks checks the list against a plain slice across many chunks.
+func TestListChunks(t *testing.T) {
+	l := New()
+	var want []string
+
+	for i := 0; i < 5000; i++ {
+		value := strconv.Itoa(rand.IntN(50))
+		switch rand.IntN(6) {
+		case 0:
+			l.PushFront(value)
+			want = slices.Insert(want, 0, value)
+		case 1, 2:
+			l.PushBack(value)
+			want = append(want, value)
+		case 3:
+			if len(want) > 0 {
+				pivot := want[rand.IntN(len(want))]
+				l.Insert(pivot, value, false)
+				i := slices.Index(want, pivot)
+				want = slices.Insert(want, i+1, value)
+			}
+		case 4:
+			if len(want) > 0 {
+				index := rand.IntN(len(want))
+				l.Set(index, value)
+				want[index] = value
+			}
+		case 5:
+			if rand.IntN(10) == 0 {
+				l.Remove(1, value)
+				if i := slices.Index(want, value); i >= 0 {
+					want = slices.Delete(want, i, i+1)
+				}
+			}
+		}
+	}
+
+	assert.Equalf(t, l.Len(), len(want), "l.Len() = %d; want %d", l.Len(), len(want))
+	assert.Equal(t, l.Range(0, -1), want)
+	for _, index := range []int{0, len(want) / 3, len(want) - 1} {
+		value, _ := l.Index(index)
+		assert.Equalf(t, value, want[index], "l.Index(%d) = %s; want %s", index, value, want[index])
+	}
+	assert.Equal(t, l.Range(100, 300), want[100:301])
+}
@@ -2,6 +2,7 @@ package resp
 
 import (
 	"bufio"
+	"io"
 	"strconv"
 )
 
@@ -134,12 +135,14 @@ func (b *BulkStringExpression) Read(reader *bufio.Reader) error {
 	if err != nil {
 		return err
 	}
-	buf := make([]byte, size)
-	_, err = reader.Read(buf)
+	// Read the value and the CRLF terminating it, so that a reader kept
+	// across commands is left at the start of the next one.
+	buf := make([]byte, size+2)
+	_, err = io.ReadFull(reader, buf)
 	if err != nil {
 		return err
 	}
-	b.value = string(buf)
+	b.value = string(buf[:size])
 	return nil
 }
 
@@ -177,6 +180,22 @@ func (n *NullBulkStringExpression) Value() interface{} {
 type NullArrayExpression struct {
 }
 
+func NewNullArrayExpression() Expression {
+	return &NullArrayExpression{}
+}
+
+func (n *NullArrayExpression) Read(reader *bufio.Reader) error {
+	return nil
+}
+
+func (n *NullArrayExpression) String() string {
+	return "NullArray"
+}
+
+func (n *NullArrayExpression) Value() interface{} {
+	return nil
+}
+
 type CRExpression struct {
 }
 
@@ -205,6 +224,19 @@ type ArrayExpression struct {
 	Expressions []Expression
 }
 
+func NewArrayExpression(expressions ...Expression) Expression {
+	return &ArrayExpression{Expressions: expressions}
+}
+
+// NewBulkStringArrayExpression returns an array of bulk strings holding values.
+func NewBulkStringArrayExpression(values []string) Expression {
+	expressions := make([]Expression, 0, len(values))
+	for _, value := range values {
+		expressions = append(expressions, NewBulkStringExpression(value))
+	}
+	return &ArrayExpression{Expressions: expressions}
+}
+
 func (a *ArrayExpression) Serialize() string {
 	var serialized string
 	serialized += string(Array) + strconv.Itoa(len(a.Expressions)) + "\r\n"
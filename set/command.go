@@ -0,0 +1,169 @@
+package set
+
+import (
+	"time"
+)
+
+type CommandSetMap[K, T comparable] interface {
+	Execute(data *setMap[K, T])
+}
+
+type addCommand[K, T comparable] struct {
+	key      K
+	members  []T
+	response chan int
+}
+
+func (c *addCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	v, ok := setMap.data[c.key]
+	if !ok {
+		v = newSetValue[T]()
+		setMap.data[c.key] = v
+	}
+
+	added := v.Set().Add(c.members...)
+	setMap.dropIfEmpty(c.key)
+	c.response <- added
+	close(c.response)
+}
+
+type removeCommand[K, T comparable] struct {
+	key      K
+	members  []T
+	response chan int
+}
+
+func (c *removeCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	removed := setMap.get(c.key).Remove(c.members...)
+	setMap.dropIfEmpty(c.key)
+	c.response <- removed
+	close(c.response)
+}
+
+type containsCommand[K, T comparable] struct {
+	key      K
+	member   T
+	response chan bool
+}
+
+func (c *containsCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	c.response <- setMap.get(c.key).Contains(c.member)
+	close(c.response)
+}
+
+type membersCommand[K, T comparable] struct {
+	key      K
+	response chan []T
+}
+
+func (c *membersCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	c.response <- setMap.get(c.key).Members()
+	close(c.response)
+}
+
+type cardCommand[K, T comparable] struct {
+	key      K
+	response chan int
+}
+
+func (c *cardCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	c.response <- setMap.get(c.key).Card()
+	close(c.response)
+}
+
+type popCommand[K, T comparable] struct {
+	key      K
+	count    int
+	response chan []T
+}
+
+func (c *popCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	popped := setMap.get(c.key).Pop(c.count)
+	setMap.dropIfEmpty(c.key)
+	c.response <- popped
+	close(c.response)
+}
+
+type randomMemberCommand[K, T comparable] struct {
+	key      K
+	count    int
+	response chan []T
+}
+
+func (c *randomMemberCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	c.response <- setMap.get(c.key).RandomMember(c.count)
+	close(c.response)
+}
+
+type algebraCommand[K, T comparable] struct {
+	keys     []K
+	op       func(sets ...*Set[T]) []T
+	response chan []T
+}
+
+func (c *algebraCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	sets := make([]*Set[T], 0, len(c.keys))
+	for _, key := range c.keys {
+		sets = append(sets, setMap.get(key))
+	}
+	c.response <- c.op(sets...)
+	close(c.response)
+}
+
+type deleteCommand[K, T comparable] struct {
+	key K
+}
+
+func (c *deleteCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	delete(setMap.data, c.key)
+}
+
+type getKeysCommand[K, T comparable] struct {
+	response chan []K
+}
+
+func (c *getKeysCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	keys := make([]K, 0, len(setMap.data))
+	for k := range setMap.data {
+		keys = append(keys, k)
+	}
+	c.response <- keys
+	close(c.response)
+}
+
+type lenCommand[K, T comparable] struct {
+	response chan int
+}
+
+func (c *lenCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	c.response <- len(setMap.data)
+	close(c.response)
+}
+
+type expireCommand[K, T comparable] struct {
+	key K
+	ttl time.Duration
+}
+
+func (c *expireCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	v, ok := setMap.data[c.key]
+	if !ok {
+		return
+	}
+	v.Expire(c.ttl)
+}
+
+type ttlCommand[K, T comparable] struct {
+	key      K
+	response chan time.Duration
+}
+
+func (c *ttlCommand[K, T]) Execute(setMap *setMap[K, T]) {
+	v, ok := setMap.data[c.key]
+	if !ok {
+		c.response <- 0
+	} else {
+		c.response <- v.TTL()
+	}
+	close(c.response)
+}
@@ -0,0 +1,167 @@
+package set
+
+import "math/rand/v2"
+
+// Set is an unordered set of distinct members, the value held by each key of a
+// SetMap. It is not safe for concurrent use: callers storing sets themselves,
+// such as the keyspace, serialize every access to them.
+type Set[T comparable] struct {
+	data map[T]struct{}
+}
+
+func NewSet[T comparable](members ...T) *Set[T] {
+	s := &Set[T]{
+		data: make(map[T]struct{}, len(members)),
+	}
+	for _, member := range members {
+		s.data[member] = struct{}{}
+	}
+	return s
+}
+
+// Add adds members to the set and returns how many were not members yet.
+func (s *Set[T]) Add(members ...T) int {
+	added := 0
+	for _, member := range members {
+		if _, ok := s.data[member]; !ok {
+			s.data[member] = struct{}{}
+			added++
+		}
+	}
+	return added
+}
+
+// Remove removes members from the set and returns how many were members.
+func (s *Set[T]) Remove(members ...T) int {
+	removed := 0
+	for _, member := range members {
+		if _, ok := s.data[member]; ok {
+			delete(s.data, member)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (s *Set[T]) Contains(member T) bool {
+	_, ok := s.data[member]
+	return ok
+}
+
+func (s *Set[T]) Members() []T {
+	members := make([]T, 0, len(s.data))
+	for member := range s.data {
+		members = append(members, member)
+	}
+	return members
+}
+
+func (s *Set[T]) Card() int {
+	return len(s.data)
+}
+
+// shuffled returns the members in random order. Map iteration order is
+// unspecified but not uniformly random, so it cannot be relied on instead.
+func (s *Set[T]) shuffled() []T {
+	members := s.Members()
+	rand.Shuffle(len(members), func(i, j int) {
+		members[i], members[j] = members[j], members[i]
+	})
+	return members
+}
+
+// Pop removes and returns up to count random members.
+func (s *Set[T]) Pop(count int) []T {
+	if count <= 0 {
+		return nil
+	}
+
+	popped := s.shuffled()
+	popped = popped[:min(count, len(popped))]
+	for _, member := range popped {
+		delete(s.data, member)
+	}
+	return popped
+}
+
+// RandomMember returns up to count distinct random members. A negative count
+// returns exactly -count members, which may repeat, so callers taking it from
+// untrusted input must bound it.
+func (s *Set[T]) RandomMember(count int) []T {
+	if count >= 0 {
+		members := s.shuffled()
+		return members[:min(count, len(members))]
+	}
+	if len(s.data) == 0 {
+		return nil
+	}
+
+	members := s.Members()
+	// -count overflows for math.MinInt, while its unsigned conversion does not.
+	n := uint(-count)
+	result := make([]T, 0, n)
+	for uint(len(result)) < n {
+		result = append(result, members[rand.IntN(len(members))])
+	}
+	return result
+}
+
+// Union returns the members found in any of sets.
+func Union[T comparable](sets ...*Set[T]) []T {
+	seen := make(map[T]struct{})
+	var result []T
+	for _, s := range sets {
+		for member := range s.data {
+			if _, ok := seen[member]; !ok {
+				seen[member] = struct{}{}
+				result = append(result, member)
+			}
+		}
+	}
+	return result
+}
+
+// Intersect returns the members found in every one of sets.
+func Intersect[T comparable](sets ...*Set[T]) []T {
+	if len(sets) == 0 {
+		return nil
+	}
+
+	var result []T
+	for member := range sets[0].data {
+		found := true
+		for _, s := range sets[1:] {
+			if !s.Contains(member) {
+				found = false
+				break
+			}
+		}
+		if found {
+			result = append(result, member)
+		}
+	}
+	return result
+}
+
+// Diff returns the members of the first set that are not found in any of the
+// others.
+func Diff[T comparable](sets ...*Set[T]) []T {
+	if len(sets) == 0 {
+		return nil
+	}
+
+	var result []T
+	for member := range sets[0].data {
+		found := false
+		for _, s := range sets[1:] {
+			if s.Contains(member) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, member)
+		}
+	}
+	return result
+}
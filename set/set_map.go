@@ -0,0 +1,207 @@
+package set
+
+import (
+	"time"
+)
+
+type SetMap[K, T comparable] interface {
+	Add(key K, members ...T) int
+	Remove(key K, members ...T) int
+	Contains(key K, member T) bool
+	Members(key K) []T
+	Card(key K) int
+	Pop(key K, count int) []T
+	RandomMember(key K, count int) []T
+	Union(keys ...K) []T
+	Intersect(keys ...K) []T
+	Diff(keys ...K) []T
+	Delete(key K)
+	Keys() []K
+	Len() int
+	TTL(key K) time.Duration
+	Expire(key K, ttl time.Duration)
+}
+
+type setMap[K, T comparable] struct {
+	data    map[K]*setValue[T]
+	command chan CommandSetMap[K, T]
+}
+
+func NewSetMap[K, T comparable]() SetMap[K, T] {
+	s := &setMap[K, T]{
+		data:    make(map[K]*setValue[T]),
+		command: make(chan CommandSetMap[K, T]),
+	}
+
+	go s.executeCommands()
+
+	return s
+}
+
+// Add adds members to the set at key, creating it if needed, and returns how
+// many were not members yet.
+func (s *setMap[K, T]) Add(key K, members ...T) int {
+	added := make(chan int)
+	s.command <- &addCommand[K, T]{key: key, members: members, response: added}
+	return <-added
+}
+
+// Remove removes members from the set at key and returns how many were
+// members. A set left empty is removed.
+func (s *setMap[K, T]) Remove(key K, members ...T) int {
+	removed := make(chan int)
+	s.command <- &removeCommand[K, T]{key: key, members: members, response: removed}
+	return <-removed
+}
+
+func (s *setMap[K, T]) Contains(key K, member T) bool {
+	found := make(chan bool)
+	s.command <- &containsCommand[K, T]{key: key, member: member, response: found}
+	return <-found
+}
+
+func (s *setMap[K, T]) Members(key K) []T {
+	members := make(chan []T)
+	s.command <- &membersCommand[K, T]{key: key, response: members}
+	return <-members
+}
+
+func (s *setMap[K, T]) Card(key K) int {
+	card := make(chan int)
+	s.command <- &cardCommand[K, T]{key: key, response: card}
+	return <-card
+}
+
+// Pop removes and returns up to count random members of the set at key.
+func (s *setMap[K, T]) Pop(key K, count int) []T {
+	members := make(chan []T)
+	s.command <- &popCommand[K, T]{key: key, count: count, response: members}
+	return <-members
+}
+
+// RandomMember returns up to count distinct random members of the set at key,
+// or exactly -count members, which may repeat, for a negative count.
+func (s *setMap[K, T]) RandomMember(key K, count int) []T {
+	members := make(chan []T)
+	s.command <- &randomMemberCommand[K, T]{key: key, count: count, response: members}
+	return <-members
+}
+
+// Union returns the members found in any of the sets at keys.
+func (s *setMap[K, T]) Union(keys ...K) []T {
+	members := make(chan []T)
+	s.command <- &algebraCommand[K, T]{keys: keys, op: Union[T], response: members}
+	return <-members
+}
+
+// Intersect returns the members found in every one of the sets at keys.
+func (s *setMap[K, T]) Intersect(keys ...K) []T {
+	members := make(chan []T)
+	s.command <- &algebraCommand[K, T]{keys: keys, op: Intersect[T], response: members}
+	return <-members
+}
+
+// Diff returns the members of the set at the first key that are not found in
+// any of the others.
+func (s *setMap[K, T]) Diff(keys ...K) []T {
+	members := make(chan []T)
+	s.command <- &algebraCommand[K, T]{keys: keys, op: Diff[T], response: members}
+	return <-members
+}
+
+func (s *setMap[K, T]) Delete(key K) {
+	s.command <- &deleteCommand[K, T]{key: key}
+}
+
+func (s *setMap[K, T]) Keys() []K {
+	keys := make(chan []K)
+	s.command <- &getKeysCommand[K, T]{response: keys}
+	return <-keys
+}
+
+func (s *setMap[K, T]) Len() int {
+	length := make(chan int)
+	s.command <- &lenCommand[K, T]{response: length}
+	return <-length
+}
+
+func (s *setMap[K, T]) TTL(key K) time.Duration {
+	ttl := make(chan time.Duration)
+	s.command <- &ttlCommand[K, T]{key: key, response: ttl}
+	return <-ttl
+}
+
+// Expire removes the set at key once ttl has elapsed. A ttl of 0 removes the
+// TTL.
+func (s *setMap[K, T]) Expire(key K, ttl time.Duration) {
+	s.command <- &expireCommand[K, T]{key: key, ttl: ttl}
+}
+
+// get returns the set at key, or an empty one if it does not exist.
+func (s *setMap[K, T]) get(key K) *Set[T] {
+	v, ok := s.data[key]
+	if !ok {
+		return NewSet[T]()
+	}
+	return v.Set()
+}
+
+// dropIfEmpty removes the set at key if it has no member left.
+func (s *setMap[K, T]) dropIfEmpty(key K) {
+	if v, ok := s.data[key]; ok && v.Set().Card() == 0 {
+		delete(s.data, key)
+	}
+}
+
+func (s *setMap[K, T]) executeCommands() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case cmd := <-s.command:
+			s.clearExpiredData()
+			cmd.Execute(s)
+		case <-ticker.C:
+			s.clearExpiredData()
+		}
+	}
+}
+
+func (s *setMap[K, T]) clearExpiredData() {
+	for k, v := range s.data {
+		if v.IsExpired() {
+			delete(s.data, k)
+		}
+	}
+}
+
+type setValue[T comparable] struct {
+	set            *Set[T]
+	ttl            time.Duration
+	lastAccessTime time.Time
+}
+
+func newSetValue[T comparable]() *setValue[T] {
+	return &setValue[T]{
+		set:            NewSet[T](),
+		lastAccessTime: time.Now(),
+	}
+}
+
+func (s *setValue[T]) Set() *Set[T] {
+	return s.set
+}
+
+func (s *setValue[T]) TTL() time.Duration {
+	return s.ttl
+}
+
+func (s *setValue[T]) Expire(ttl time.Duration) {
+	s.ttl = ttl
+	s.lastAccessTime = time.Now()
+}
+
+func (s *setValue[T]) IsExpired() bool {
+	return s.ttl > 0 && time.Since(s.lastAccessTime) > s.ttl
+}
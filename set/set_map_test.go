@@ -0,0 +1,82 @@
+package set
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSetMap(t *testing.T) {
+	setMap := NewSetMap[string, string]()
+
+	added := setMap.Add("tags", "go", "cache", "go")
+	assert.Equalf(t, added, 2, "setMap.Add(tags, go, cache, go) = %d; want 2", added)
+	setMap.Add("langs", "go", "rust")
+	assert.Equalf(t, setMap.Len(), 2, "setMap.Len() = %d; want 2", setMap.Len())
+	assert.ElementsMatchf(t, setMap.Keys(), []string{"tags", "langs"}, "setMap.Keys() = %v", setMap.Keys())
+	assert.Truef(t, setMap.Contains("tags", "go"), "setMap.Contains(tags, go) = false; want true")
+	assert.Falsef(t, setMap.Contains("missing", "go"), "setMap.Contains(missing, go) = true; want false")
+	assert.Equalf(t, setMap.Card("tags"), 2, "setMap.Card(tags) = %d; want 2", setMap.Card("tags"))
+	assert.ElementsMatchf(t, setMap.Members("tags"), []string{"go", "cache"}, "setMap.Members(tags) = %v", setMap.Members("tags"))
+	assert.Lenf(t, setMap.RandomMember("tags", -3), 3, "len(setMap.RandomMember(tags, -3)) = %d; want 3", len(setMap.RandomMember("tags", -3)))
+
+	assert.ElementsMatch(t, setMap.Union("tags", "langs", "missing"), []string{"go", "cache", "rust"})
+	assert.ElementsMatch(t, setMap.Intersect("tags", "langs"), []string{"go"})
+	assert.ElementsMatch(t, setMap.Diff("tags", "langs"), []string{"cache"})
+	assert.Emptyf(t, setMap.Intersect("tags", "missing"), "setMap.Intersect(tags, missing) = %v; want empty", setMap.Intersect("tags", "missing"))
+
+	// A set left empty is removed.
+	removed := setMap.Remove("langs", "go", "rust", "c")
+	assert.Equalf(t, removed, 2, "setMap.Remove(langs, go, rust, c) = %d; want 2", removed)
+	popped := setMap.Pop("tags", 5)
+	assert.Lenf(t, popped, 2, "len(setMap.Pop(tags, 5)) = %d; want 2", len(popped))
+	assert.Zerof(t, setMap.Len(), "setMap.Len() = %d; want 0", setMap.Len())
+	assert.Zerof(t, setMap.Add("empty"), "setMap.Add(empty) = %d; want 0", setMap.Add("empty"))
+	assert.Zerof(t, setMap.Len(), "setMap.Len() = %d after adding no member; want 0", setMap.Len())
+
+	setMap.Add("tags", "go")
+	setMap.Delete("tags")
+	assert.Zerof(t, setMap.Card("tags"), "setMap.Card(tags) = %d after Delete; want 0", setMap.Card("tags"))
+}
+
+func TestSetMapTTL(t *testing.T) {
+	setMap := NewSetMap[string, int]()
+	setMap.Add("a", 1, 2)
+	setMap.Add("b", 3)
+
+	setMap.Expire("a", 100*time.Millisecond)
+	assert.Equalf(t, setMap.TTL("a"), 100*time.Millisecond, "setMap.TTL(a) = %s; want 100ms", setMap.TTL("a"))
+	assert.Zerof(t, setMap.TTL("b"), "setMap.TTL(b) = %s; want 0", setMap.TTL("b"))
+	time.Sleep(200 * time.Millisecond)
+	assert.Falsef(t, setMap.Contains("a", 1), "setMap.Contains(a, 1) = true after the TTL; want false")
+	assert.Equalf(t, setMap.Len(), 1, "setMap.Len() = %d; want 1", setMap.Len())
+
+	setMap.Expire("b", time.Minute)
+	setMap.Expire("b", 0)
+	time.Sleep(10 * time.Millisecond)
+	assert.Truef(t, setMap.Contains("b", 3), "setMap.Contains(b, 3) = false after removing the TTL; want true")
+}
+
+func TestConcurrencySetMap(t *testing.T) {
+	setMap := NewSetMap[string, int]()
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(i int) {
+			key := fmt.Sprintf("set:%d", i%100)
+			setMap.Add(key, i)
+			setMap.Expire(key, time.Second)
+			setMap.TTL(key)
+			wg.Done()
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equalf(t, setMap.Len(), 100, "setMap.Len() = %d; want 100", setMap.Len())
+	assert.Equalf(t, setMap.Card("set:0"), 10, "setMap.Card(set:0) = %d; want 10", setMap.Card("set:0"))
+}
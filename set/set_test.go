@@ -0,0 +1,47 @@
+package set
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSet(t *testing.T) {
+	s := NewSet[string]("a", "b")
+
+	added := s.Add("b", "c", "d")
+	assert.Equalf(t, added, 2, "s.Add(b, c, d) = %d; want 2", added)
+	assert.Equalf(t, s.Card(), 4, "s.Card() = %d; want 4", s.Card())
+	assert.Truef(t, s.Contains("c"), "s.Contains(c) = false; want true")
+	removed := s.Remove("c", "z")
+	assert.Equalf(t, removed, 1, "s.Remove(c, z) = %d; want 1", removed)
+	assert.Falsef(t, s.Contains("c"), "s.Contains(c) = true; want false")
+	assert.ElementsMatchf(t, s.Members(), []string{"a", "b", "d"}, "s.Members() = %v", s.Members())
+
+	random := s.RandomMember(2)
+	assert.Lenf(t, random, 2, "len(s.RandomMember(2)) = %d; want 2", len(random))
+	random = s.RandomMember(-5)
+	assert.Lenf(t, random, 5, "len(s.RandomMember(-5)) = %d; want 5", len(random))
+
+	popped := s.Pop(2)
+	assert.Lenf(t, popped, 2, "len(s.Pop(2)) = %d; want 2", len(popped))
+	assert.Equalf(t, s.Card(), 1, "s.Card() = %d; want 1", s.Card())
+	assert.Emptyf(t, s.Pop(-1), "s.Pop(-1) = %v; want empty", s.Pop(-1))
+}
+
+func TestSetRandomMemberOfEmptySet(t *testing.T) {
+	s := NewSet[string]()
+	assert.Emptyf(t, s.RandomMember(3), "s.RandomMember(3) = %v; want empty", s.RandomMember(3))
+	assert.Emptyf(t, s.RandomMember(math.MinInt), "s.RandomMember(MinInt) = %v; want empty", s.RandomMember(math.MinInt))
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+	c := NewSet(4, 6)
+
+	assert.ElementsMatch(t, Union(a, b, c), []int{1, 2, 3, 4, 5, 6})
+	assert.ElementsMatch(t, Intersect(a, b, c), []int{4})
+	assert.ElementsMatch(t, Diff(a, b, c), []int{1, 2})
+}
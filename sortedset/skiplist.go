@@ -0,0 +1,214 @@
+package sortedset
+
+import "math/rand/v2"
+
+const (
+	skiplistMaxLevel = 32
+	skiplistP        = 0.25
+)
+
+type skiplistLevel struct {
+	forward *skiplistNode
+	span    int
+}
+
+type skiplistNode struct {
+	member   string
+	score    float64
+	backward *skiplistNode
+	level    []skiplistLevel
+}
+
+func newSkiplistNode(level int, score float64, member string) *skiplistNode {
+	return &skiplistNode{
+		member: member,
+		score:  score,
+		level:  make([]skiplistLevel, level),
+	}
+}
+
+// skiplist keeps members ordered by score, then by member, and records in
+// every link the number of nodes it skips so that ranks can be computed while
+// searching, as the Redis zset skiplist does.
+type skiplist struct {
+	header *skiplistNode
+	tail   *skiplistNode
+	length int
+	level  int
+}
+
+func newSkiplist() *skiplist {
+	return &skiplist{
+		header: newSkiplistNode(skiplistMaxLevel, 0, ""),
+		level:  1,
+	}
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skiplistMaxLevel && rand.Float64() < skiplistP {
+		level++
+	}
+	return level
+}
+
+// before reports whether the node holds an element ordered before score and
+// member.
+func (n *skiplistNode) before(score float64, member string) bool {
+	return n.score < score || (n.score == score && n.member < member)
+}
+
+func (l *skiplist) insert(score float64, member string) *skiplistNode {
+	var update [skiplistMaxLevel]*skiplistNode
+	var rank [skiplistMaxLevel]int
+
+	x := l.header
+	for i := l.level - 1; i >= 0; i-- {
+		if i < l.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.level[i].forward != nil && x.level[i].forward.before(score, member) {
+			rank[i] += x.level[i].span
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+
+	level := randomLevel()
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = l.header
+			update[i].level[i].span = l.length
+		}
+		l.level = level
+	}
+
+	x = newSkiplistNode(level, score, member)
+	for i := 0; i < level; i++ {
+		x.level[i].forward = update[i].level[i].forward
+		update[i].level[i].forward = x
+		x.level[i].span = update[i].level[i].span - (rank[0] - rank[i])
+		update[i].level[i].span = rank[0] - rank[i] + 1
+	}
+	for i := level; i < l.level; i++ {
+		update[i].level[i].span++
+	}
+
+	if update[0] != l.header {
+		x.backward = update[0]
+	}
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x
+	} else {
+		l.tail = x
+	}
+	l.length++
+	return x
+}
+
+func (l *skiplist) deleteNode(x *skiplistNode, update []*skiplistNode) {
+	for i := 0; i < l.level; i++ {
+		if update[i].level[i].forward == x {
+			update[i].level[i].span += x.level[i].span - 1
+			update[i].level[i].forward = x.level[i].forward
+		} else {
+			update[i].level[i].span--
+		}
+	}
+
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x.backward
+	} else {
+		l.tail = x.backward
+	}
+	for l.level > 1 && l.header.level[l.level-1].forward == nil {
+		l.level--
+	}
+	l.length--
+}
+
+func (l *skiplist) delete(score float64, member string) bool {
+	update := make([]*skiplistNode, skiplistMaxLevel)
+
+	x := l.header
+	for i := l.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && x.level[i].forward.before(score, member) {
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+
+	x = x.level[0].forward
+	if x == nil || x.score != score || x.member != member {
+		return false
+	}
+	l.deleteNode(x, update)
+	return true
+}
+
+// rank returns the 1-based rank of the element, or 0 if it is not found.
+func (l *skiplist) rank(score float64, member string) int {
+	rank := 0
+	x := l.header
+	for i := l.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil &&
+			(x.level[i].forward.before(score, member) || (x.level[i].forward.score == score && x.level[i].forward.member == member)) {
+			rank += x.level[i].span
+			x = x.level[i].forward
+		}
+		if x != l.header && x.score == score && x.member == member {
+			return rank
+		}
+	}
+	return 0
+}
+
+// byRank returns the node at the 1-based rank.
+func (l *skiplist) byRank(rank int) *skiplistNode {
+	traversed := 0
+	x := l.header
+	for i := l.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && traversed+x.level[i].span <= rank {
+			traversed += x.level[i].span
+			x = x.level[i].forward
+		}
+		if traversed == rank {
+			return x
+		}
+	}
+	return nil
+}
+
+// first returns the first node accepted by aboveMin, if belowMax accepts it
+// too. aboveMin must reject a prefix of the list and accept the rest of it.
+func (l *skiplist) first(aboveMin func(n *skiplistNode) bool, belowMax func(n *skiplistNode) bool) *skiplistNode {
+	x := l.header
+	for i := l.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && !aboveMin(x.level[i].forward) {
+			x = x.level[i].forward
+		}
+	}
+
+	x = x.level[0].forward
+	if x == nil || !belowMax(x) {
+		return nil
+	}
+	return x
+}
+
+// last returns the last node accepted by belowMax, if aboveMin accepts it
+// too. belowMax must accept a prefix of the list and reject the rest of it.
+func (l *skiplist) last(aboveMin func(n *skiplistNode) bool, belowMax func(n *skiplistNode) bool) *skiplistNode {
+	x := l.header
+	for i := l.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && belowMax(x.level[i].forward) {
+			x = x.level[i].forward
+		}
+	}
+
+	if x == l.header || !aboveMin(x) {
+		return nil
+	}
+	return x
+}
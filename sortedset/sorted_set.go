@@ -0,0 +1,322 @@
+package sortedset
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNaN is returned by Add when an increment results in a score that is not
+// a number.
+var ErrNaN = errors.New("resulting score is not a number (NaN)")
+
+type Entry struct {
+	Member string
+	Score  float64
+}
+
+// AddOptions mirror the flags of ZADD.
+type AddOptions struct {
+	NX   bool // only add new members
+	XX   bool // only update existing members
+	GT   bool // only update when the new score is greater
+	LT   bool // only update when the new score is lower
+	Incr bool // add score to the current score instead of replacing it
+}
+
+type AddResult int
+
+const (
+	Skipped AddResult = iota
+	Added
+	Updated
+	Unchanged
+)
+
+// ScoreRange selects the scores between Min and Max.
+type ScoreRange struct {
+	Min, Max                   float64
+	MinExclusive, MaxExclusive bool
+}
+
+func (r ScoreRange) aboveMin(n *skiplistNode) bool {
+	if r.MinExclusive {
+		return n.score > r.Min
+	}
+	return n.score >= r.Min
+}
+
+func (r ScoreRange) belowMax(n *skiplistNode) bool {
+	if r.MaxExclusive {
+		return n.score < r.Max
+	}
+	return n.score <= r.Max
+}
+
+func (r ScoreRange) isEmpty() bool {
+	return r.Min > r.Max || (r.Min == r.Max && (r.MinExclusive || r.MaxExclusive))
+}
+
+// LexRange selects the members between Min and Max when all members have the
+// same score. An unbounded side matches every member.
+type LexRange struct {
+	Min, Max                   string
+	MinExclusive, MaxExclusive bool
+	MinUnbounded, MaxUnbounded bool
+}
+
+func (r LexRange) aboveMin(n *skiplistNode) bool {
+	switch {
+	case r.MinUnbounded:
+		return true
+	case r.MinExclusive:
+		return n.member > r.Min
+	default:
+		return n.member >= r.Min
+	}
+}
+
+func (r LexRange) belowMax(n *skiplistNode) bool {
+	switch {
+	case r.MaxUnbounded:
+		return true
+	case r.MaxExclusive:
+		return n.member < r.Max
+	default:
+		return n.member <= r.Max
+	}
+}
+
+func (r LexRange) isEmpty() bool {
+	if r.MinUnbounded || r.MaxUnbounded {
+		return false
+	}
+	return r.Min > r.Max || (r.Min == r.Max && (r.MinExclusive || r.MaxExclusive))
+}
+
+// SortedSet is a set of string members ordered by score, backed by a skiplist
+// and a map from member to score. It is not safe for concurrent use.
+type SortedSet struct {
+	scores map[string]float64
+	zsl    *skiplist
+}
+
+func New() *SortedSet {
+	return &SortedSet{
+		scores: make(map[string]float64),
+		zsl:    newSkiplist(),
+	}
+}
+
+// Add adds member with score, or updates its score, according to opts. It
+// returns the score of the member after the call and what was done.
+func (z *SortedSet) Add(member string, score float64, opts AddOptions) (float64, AddResult, error) {
+	current, exists := z.scores[member]
+	if !exists {
+		if opts.XX {
+			return 0, Skipped, nil
+		}
+		if math.IsNaN(score) {
+			return 0, Skipped, ErrNaN
+		}
+		z.scores[member] = score
+		z.zsl.insert(score, member)
+		return score, Added, nil
+	}
+
+	if opts.NX {
+		return current, Skipped, nil
+	}
+	if opts.Incr {
+		score += current
+		if math.IsNaN(score) {
+			return current, Skipped, ErrNaN
+		}
+	}
+	if (opts.GT && score <= current) || (opts.LT && score >= current) {
+		return current, Skipped, nil
+	}
+	if score == current {
+		return current, Unchanged, nil
+	}
+
+	z.zsl.delete(current, member)
+	z.zsl.insert(score, member)
+	z.scores[member] = score
+	return score, Updated, nil
+}
+
+// IncrBy adds increment to the score of member, adding it with that score if
+// it does not exist, and returns the new score.
+func (z *SortedSet) IncrBy(member string, increment float64) (float64, error) {
+	score, _, err := z.Add(member, increment, AddOptions{Incr: true})
+	return score, err
+}
+
+func (z *SortedSet) Remove(members ...string) int {
+	removed := 0
+	for _, member := range members {
+		score, ok := z.scores[member]
+		if !ok {
+			continue
+		}
+		z.zsl.delete(score, member)
+		delete(z.scores, member)
+		removed++
+	}
+	return removed
+}
+
+func (z *SortedSet) Score(member string) (float64, bool) {
+	score, ok := z.scores[member]
+	return score, ok
+}
+
+func (z *SortedSet) Card() int {
+	return len(z.scores)
+}
+
+// Rank returns the 0-based position of member, counted from the lowest score,
+// or from the highest one when reverse is set.
+func (z *SortedSet) Rank(member string, reverse bool) (int, bool) {
+	score, ok := z.scores[member]
+	if !ok {
+		return 0, false
+	}
+
+	rank := z.zsl.rank(score, member)
+	if reverse {
+		return z.zsl.length - rank, true
+	}
+	return rank - 1, true
+}
+
+// Count returns the number of members whose score is in r.
+func (z *SortedSet) Count(r ScoreRange) int {
+	if r.isEmpty() {
+		return 0
+	}
+
+	first := z.zsl.first(r.aboveMin, r.belowMax)
+	if first == nil {
+		return 0
+	}
+	last := z.zsl.last(r.aboveMin, r.belowMax)
+	return z.zsl.rank(last.score, last.member) - z.zsl.rank(first.score, first.member) + 1
+}
+
+// RangeByRank returns the members from position start to stop included.
+// Negative positions count from the end, as in ZRANGE.
+func (z *SortedSet) RangeByRank(start, stop int, reverse bool) []Entry {
+	length := z.zsl.length
+	if start < 0 {
+		start += length
+	}
+	if stop < 0 {
+		stop += length
+	}
+	start = max(start, 0)
+	if start > stop || start >= length {
+		return nil
+	}
+	stop = min(stop, length-1)
+
+	entries := make([]Entry, 0, stop-start+1)
+	if reverse {
+		for x := z.zsl.byRank(length - start); x != nil && len(entries) < cap(entries); x = x.backward {
+			entries = append(entries, Entry{Member: x.member, Score: x.score})
+		}
+		return entries
+	}
+
+	for x := z.zsl.byRank(start + 1); x != nil && len(entries) < cap(entries); x = x.level[0].forward {
+		entries = append(entries, Entry{Member: x.member, Score: x.score})
+	}
+	return entries
+}
+
+// RangeByScore returns the members whose score is in r, skipping the first
+// offset ones and returning at most count of them, or all of them if count is
+// negative.
+func (z *SortedSet) RangeByScore(r ScoreRange, reverse bool, offset, count int) []Entry {
+	if r.isEmpty() {
+		return nil
+	}
+	return z.rangeBetween(r.aboveMin, r.belowMax, reverse, offset, count)
+}
+
+// RangeByLex returns the members in r, like RangeByScore.
+func (z *SortedSet) RangeByLex(r LexRange, reverse bool, offset, count int) []Entry {
+	if r.isEmpty() {
+		return nil
+	}
+	return z.rangeBetween(r.aboveMin, r.belowMax, reverse, offset, count)
+}
+
+func (z *SortedSet) rangeBetween(aboveMin, belowMax func(n *skiplistNode) bool, reverse bool, offset, count int) []Entry {
+	var x *skiplistNode
+	if reverse {
+		x = z.zsl.last(aboveMin, belowMax)
+	} else {
+		x = z.zsl.first(aboveMin, belowMax)
+	}
+
+	next := func(n *skiplistNode) *skiplistNode {
+		if reverse {
+			return n.backward
+		}
+		return n.level[0].forward
+	}
+	for ; x != nil && offset > 0; offset-- {
+		x = next(x)
+	}
+
+	var entries []Entry
+	for ; x != nil && count != 0; x = next(x) {
+		if !aboveMin(x) || !belowMax(x) {
+			break
+		}
+		entries = append(entries, Entry{Member: x.member, Score: x.score})
+		count--
+	}
+	return entries
+}
+
+func (z *SortedSet) removeEntries(entries []Entry) int {
+	for _, entry := range entries {
+		z.zsl.delete(entry.Score, entry.Member)
+		delete(z.scores, entry.Member)
+	}
+	return len(entries)
+}
+
+func (z *SortedSet) RemoveRangeByRank(start, stop int) int {
+	return z.removeEntries(z.RangeByRank(start, stop, false))
+}
+
+func (z *SortedSet) RemoveRangeByScore(r ScoreRange) int {
+	return z.removeEntries(z.RangeByScore(r, false, 0, -1))
+}
+
+func (z *SortedSet) RemoveRangeByLex(r LexRange) int {
+	return z.removeEntries(z.RangeByLex(r, false, 0, -1))
+}
+
+// PopMin removes and returns up to count members with the lowest scores.
+func (z *SortedSet) PopMin(count int) []Entry {
+	if count <= 0 {
+		return nil
+	}
+	entries := z.RangeByRank(0, count-1, false)
+	z.removeEntries(entries)
+	return entries
+}
+
+// PopMax removes and returns up to count members with the highest scores.
+func (z *SortedSet) PopMax(count int) []Entry {
+	if count <= 0 {
+		return nil
+	}
+	entries := z.RangeByRank(0, count-1, true)
+	z.removeEntries(entries)
+	return entries
+}
@@ -0,0 +1,116 @@
+package sortedset
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func members(entries []Entry) []string {
+	result := make([]string, len(entries))
+	for i, entry := range entries {
+		result[i] = entry.Member
+	}
+	return result
+}
+
+func TestSortedSet(t *testing.T) {
+	z := New()
+
+	for i, member := range []string{"a", "b", "c", "d", "e"} {
+		_, result, err := z.Add(member, float64(i+1), AddOptions{})
+		assert.NoError(t, err)
+		assert.Equalf(t, result, Added, "z.Add(%s) = %d; want Added", member, result)
+	}
+	assert.Equalf(t, z.Card(), 5, "z.Card() = %d; want 5", z.Card())
+
+	_, result, _ := z.Add("a", 10, AddOptions{NX: true})
+	assert.Equalf(t, result, Skipped, "z.Add(a, NX) = %d; want Skipped", result)
+	_, result, _ = z.Add("z", 10, AddOptions{XX: true})
+	assert.Equalf(t, result, Skipped, "z.Add(z, XX) = %d; want Skipped", result)
+	_, result, _ = z.Add("b", 1, AddOptions{GT: true})
+	assert.Equalf(t, result, Skipped, "z.Add(b, 1, GT) = %d; want Skipped", result)
+	_, result, _ = z.Add("b", 2, AddOptions{})
+	assert.Equalf(t, result, Unchanged, "z.Add(b, 2) = %d; want Unchanged", result)
+
+	score, err := z.IncrBy("a", 5)
+	assert.NoError(t, err)
+	assert.Equalf(t, score, 6.0, "z.IncrBy(a, 5) = %v; want 6", score)
+	_, err = z.IncrBy("inf", math.Inf(1))
+	assert.NoError(t, err)
+	_, err = z.IncrBy("inf", math.Inf(-1))
+	assert.ErrorIs(t, err, ErrNaN)
+
+	rank, ok := z.Rank("a", false)
+	assert.Truef(t, ok, "z.Rank(a) not found")
+	assert.Equalf(t, rank, 4, "z.Rank(a) = %d; want 4", rank)
+	rank, _ = z.Rank("a", true)
+	assert.Equalf(t, rank, 1, "z.Rank(a, reverse) = %d; want 1", rank)
+
+	assert.Equal(t, members(z.RangeByRank(0, -1, false)), []string{"b", "c", "d", "e", "a", "inf"})
+	assert.Equal(t, members(z.RangeByRank(-2, 10, true)), []string{"c", "b"})
+	assert.Empty(t, z.RangeByRank(5, 2, false))
+
+	r := ScoreRange{Min: 2, Max: 5, MinExclusive: true}
+	assert.Equalf(t, z.Count(r), 3, "z.Count((2, 5]) = %d; want 3", z.Count(r))
+	assert.Equal(t, members(z.RangeByScore(r, false, 1, 1)), []string{"d"})
+	assert.Equal(t, members(z.RangeByScore(r, true, 0, -1)), []string{"e", "d", "c"})
+
+	removed := z.Remove("inf", "missing")
+	assert.Equalf(t, removed, 1, "z.Remove(inf, missing) = %d; want 1", removed)
+	assert.Equal(t, z.PopMin(2), []Entry{{"b", 2}, {"c", 3}})
+	assert.Equal(t, z.PopMax(1), []Entry{{"a", 6}})
+	assert.Equalf(t, z.Card(), 2, "z.Card() = %d; want 2", z.Card())
+}
+
+func TestSortedSetLex(t *testing.T) {
+	z := New()
+	for _, member := range []string{"a", "b", "c", "d", "e", "f"} {
+		_, _, _ = z.Add(member, 0, AddOptions{})
+	}
+
+	assert.Equal(t, members(z.RangeByLex(LexRange{Min: "b", Max: "d", MaxExclusive: true}, false, 0, -1)), []string{"b", "c"})
+	assert.Equal(t, members(z.RangeByLex(LexRange{MinUnbounded: true, Max: "c"}, true, 0, -1)), []string{"c", "b", "a"})
+	assert.Empty(t, z.RangeByLex(LexRange{Min: "d", Max: "b"}, false, 0, -1))
+
+	removed := z.RemoveRangeByLex(LexRange{Min: "e", MaxUnbounded: true})
+	assert.Equalf(t, removed, 2, "z.RemoveRangeByLex([e, +) = %d; want 2", removed)
+	removed = z.RemoveRangeByRank(0, 0)
+	assert.Equalf(t, removed, 1, "z.RemoveRangeByRank(0, 0) = %d; want 1", removed)
+	assert.Equal(t, members(z.RangeByRank(0, -1, false)), []string{"b", "c", "d"})
+}
+
+func TestSortedSetRanks(t *testing.T) {
+	z := New()
+	want := make([]string, 0, 1000)
+	for _, i := range rand.Perm(1000) {
+		member := fmt.Sprintf("m%04d", i)
+		_, _, _ = z.Add(member, float64(i%100), AddOptions{})
+		want = append(want, member)
+	}
+	sort.Slice(want, func(i, j int) bool {
+		var a, b int
+		_, _ = fmt.Sscanf(want[i], "m%d", &a)
+		_, _ = fmt.Sscanf(want[j], "m%d", &b)
+		if a%100 != b%100 {
+			return a%100 < b%100
+		}
+		return want[i] < want[j]
+	})
+
+	for i, member := range want {
+		rank, _ := z.Rank(member, false)
+		if rank != i {
+			t.Fatalf("z.Rank(%s) = %d; want %d", member, rank, i)
+		}
+	}
+	assert.Equal(t, members(z.RangeByRank(0, -1, false)), want)
+
+	removed := z.RemoveRangeByScore(ScoreRange{Min: 0, Max: 49})
+	assert.Equalf(t, removed, 500, "z.RemoveRangeByScore([0, 49]) = %d; want 500", removed)
+	assert.Equal(t, members(z.RangeByRank(0, -1, false)), want[500:])
+}
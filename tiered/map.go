@@ -0,0 +1,329 @@
+package tiered
+
+import (
+	"log"
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/gomap"
+)
+
+// Map is a gomap.Map whose entries evicted from memory are spilled to an
+// on-disk segment store and promoted back to memory when they are read.
+//
+// Keys and values must be encodable with encoding/gob. Versions, pins and the
+// whole-map TTL only apply to the entries currently held in memory.
+type Map[K, V comparable] interface {
+	gomap.Map[K, V]
+	Close() error
+}
+
+type tieredMap[K, V comparable] struct {
+	gomap.Map[K, V]
+	disk *diskStore[K, V]
+	done chan struct{}
+}
+
+// NewMap creates a Map that keeps at most capacity entries in memory and
+// stores the entries it evicts in segment files under dir.
+func NewMap[K, V comparable](dir string, capacity int, opts ...Option) (Map[K, V], error) {
+	o := newOptions(opts)
+
+	disk, err := newDiskStore[K, V](dir, o.segmentSize)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &tieredMap[K, V]{
+		disk: disk,
+		done: make(chan struct{}),
+	}
+
+	mapOptions := append(o.mapOptions, gomap.WithCapacity(capacity), gomap.WithOnEvict(m.spill))
+	m.Map = gomap.NewMap[K, V](mapOptions...)
+
+	go m.compactPeriodically(o.compactionInterval)
+
+	return m, nil
+}
+
+// spill is called by the memory tier with every entry it evicts.
+func (m *tieredMap[K, V]) spill(entry gomap.Entry[K, V]) {
+	// An entry that cannot be written is dropped, as it would have been
+	// without a spill tier.
+	_ = m.disk.put(entry.Key, entry.Value, entry.TTL, entry.Tags)
+}
+
+func (m *tieredMap[K, V]) compactPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = m.disk.compact()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// promote moves key from disk back to memory if it is only stored on disk.
+func (m *tieredMap[K, V]) promote(key K) {
+	if !m.disk.contains(key) {
+		return
+	}
+
+	_ = m.Map.Txn(func(tx gomap.Tx[K, V]) error {
+		_, _ = m.promoteTx(tx, key)
+		return nil
+	})
+}
+
+// promoteTx moves key from disk to memory through tx. It must only be called
+// from a transaction callback.
+func (m *tieredMap[K, V]) promoteTx(tx gomap.Tx[K, V], key K) (value V, ok bool) {
+	if value, ok = tx.Get(key); ok {
+		return value, true
+	}
+
+	value, ttl, tags, ok, err := m.disk.take(key)
+	if err != nil || !ok {
+		return value, false
+	}
+
+	tx.SetWithTags(key, value, tags...)
+	tx.ExpireKey(key, ttl)
+	return value, true
+}
+
+func (m *tieredMap[K, V]) Get(key K) (value V, ok bool) {
+	value, ok = m.Map.Get(key)
+	if ok || !m.disk.contains(key) {
+		return value, ok
+	}
+
+	_ = m.Map.Txn(func(tx gomap.Tx[K, V]) error {
+		value, ok = m.promoteTx(tx, key)
+		return nil
+	})
+	return value, ok
+}
+
+func (m *tieredMap[K, V]) TryGet(key K) (value V, ok bool, err error) {
+	value, ok, err = m.Map.TryGet(key)
+	if ok || err != nil {
+		return value, ok, err
+	}
+	return m.disk.get(key)
+}
+
+func (m *tieredMap[K, V]) GetWithVersion(key K) (V, uint64, bool) {
+	m.promote(key)
+	return m.Map.GetWithVersion(key)
+}
+
+func (m *tieredMap[K, V]) SetIfVersion(key K, value V, version uint64) error {
+	m.promote(key)
+	return m.Map.SetIfVersion(key, value, version)
+}
+
+func (m *tieredMap[K, V]) Set(key K, value V) {
+	_ = m.Txn(func(tx gomap.Tx[K, V]) error {
+		tx.Set(key, value)
+		return nil
+	})
+}
+
+func (m *tieredMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	_ = m.Txn(func(tx gomap.Tx[K, V]) error {
+		tx.Set(key, value)
+		tx.ExpireKey(key, ttl)
+		return nil
+	})
+}
+
+func (m *tieredMap[K, V]) SetWithTags(key K, value V, tags ...string) {
+	_ = m.Txn(func(tx gomap.Tx[K, V]) error {
+		tx.SetWithTags(key, value, tags...)
+		return nil
+	})
+}
+
+// TrySet goes through TryTxn, so that a spilled key is only removed from disk
+// inside the event loop, and keeps its tags as it does with Set.
+func (m *tieredMap[K, V]) TrySet(key K, value V) error {
+	return m.TryTxn(func(tx gomap.Tx[K, V]) error {
+		tx.Set(key, value)
+		return nil
+	})
+}
+
+func (m *tieredMap[K, V]) Delete(key K) {
+	_ = m.Txn(func(tx gomap.Tx[K, V]) error {
+		tx.Delete(key)
+		return nil
+	})
+}
+
+func (m *tieredMap[K, V]) InvalidateTag(tag string) int {
+	return m.Map.InvalidateTag(tag) + m.disk.invalidateTag(tag)
+}
+
+func (m *tieredMap[K, V]) Pin(key K) {
+	m.promote(key)
+	m.Map.Pin(key)
+}
+
+// Keys lists the keys of both tiers from inside the memory tier's event loop,
+// where every spill and promotion happens, so that no key moves between the
+// tiers while they are read.
+func (m *tieredMap[K, V]) Keys() []K {
+	var keys []K
+	_ = m.Txn(func(tx gomap.Tx[K, V]) error {
+		keys = tx.Keys()
+		return nil
+	})
+	return keys
+}
+
+func (m *tieredMap[K, V]) Values() []V {
+	var values []V
+	_ = m.Map.Txn(func(tx gomap.Tx[K, V]) error {
+		keys := tx.Keys()
+		inMemory := make(map[K]struct{}, len(keys))
+		for _, key := range keys {
+			value, _ := tx.Get(key)
+			values = append(values, value)
+			inMemory[key] = struct{}{}
+		}
+
+		spilled, err := m.disk.values(inMemory)
+		if err != nil {
+			log.Printf("tiered: reading spilled values: %v", err)
+		}
+		values = append(values, spilled...)
+		return nil
+	})
+	return values
+}
+
+func (m *tieredMap[K, V]) Len() int {
+	return len(m.Keys())
+}
+
+func (m *tieredMap[K, V]) TTLKey(key K) time.Duration {
+	if m.disk.contains(key) {
+		return m.disk.ttl(key)
+	}
+	return m.Map.TTLKey(key)
+}
+
+func (m *tieredMap[K, V]) ExpireKey(key K, ttl time.Duration) {
+	_ = m.Txn(func(tx gomap.Tx[K, V]) error {
+		tx.ExpireKey(key, ttl)
+		return nil
+	})
+}
+
+func (m *tieredMap[K, V]) Expire(ttl time.Duration) {
+	m.Map.Expire(ttl)
+	m.disk.expireAll(ttl)
+}
+
+// Txn runs fn against both tiers: keys read or written through tx are
+// promoted to memory if they were spilled to disk. The writes of every other
+// method go through Txn as well, so that they are ordered with the spills and
+// promotions happening in the memory tier's event loop.
+func (m *tieredMap[K, V]) Txn(fn func(tx gomap.Tx[K, V]) error) error {
+	return m.Map.Txn(m.tieredTxn(fn))
+}
+
+func (m *tieredMap[K, V]) TryTxn(fn func(tx gomap.Tx[K, V]) error) error {
+	return m.Map.TryTxn(m.tieredTxn(fn))
+}
+
+// tieredTxn wraps fn to run it against both tiers, removing the keys it wrote
+// from disk once it succeeds.
+func (m *tieredMap[K, V]) tieredTxn(fn func(tx gomap.Tx[K, V]) error) func(tx gomap.Tx[K, V]) error {
+	return func(tx gomap.Tx[K, V]) error {
+		t := &tieredTx[K, V]{Tx: tx, m: m, written: make(map[K]struct{})}
+		err := fn(t)
+		if err != nil {
+			return err
+		}
+
+		for key := range t.written {
+			m.disk.remove(key)
+		}
+		return nil
+	}
+}
+
+func (m *tieredMap[K, V]) Close() error {
+	close(m.done)
+	return m.disk.close()
+}
+
+// tieredTx defers promotion of spilled keys to the commit of the transaction:
+// the values read from disk are staged in tx, and only removed from disk once
+// the callback succeeds.
+type tieredTx[K, V comparable] struct {
+	gomap.Tx[K, V]
+	m       *tieredMap[K, V]
+	written map[K]struct{}
+}
+
+func (t *tieredTx[K, V]) Get(key K) (value V, ok bool) {
+	if value, ok = t.Tx.Get(key); ok {
+		return value, true
+	}
+	if _, staged := t.written[key]; staged {
+		return value, false
+	}
+
+	value, ttl, tags, ok, err := t.m.disk.peek(key)
+	if err != nil || !ok {
+		return value, false
+	}
+
+	t.Tx.SetWithTags(key, value, tags...)
+	t.Tx.ExpireKey(key, ttl)
+	t.written[key] = struct{}{}
+	return value, true
+}
+
+// Keys returns the keys held in memory followed by the ones only stored on
+// disk, leaving out the keys deleted by the transaction.
+func (t *tieredTx[K, V]) Keys() []K {
+	keys := t.Tx.Keys()
+	skip := make(map[K]struct{}, len(keys)+len(t.written))
+	for _, key := range keys {
+		skip[key] = struct{}{}
+	}
+	for key := range t.written {
+		skip[key] = struct{}{}
+	}
+	return append(keys, t.m.disk.keys(skip)...)
+}
+
+func (t *tieredTx[K, V]) Set(key K, value V) {
+	// Promote a spilled key first so that it keeps its tags, as Set does.
+	t.Get(key)
+	t.Tx.Set(key, value)
+	t.written[key] = struct{}{}
+}
+
+func (t *tieredTx[K, V]) SetWithTags(key K, value V, tags ...string) {
+	t.Tx.SetWithTags(key, value, tags...)
+	t.written[key] = struct{}{}
+}
+
+func (t *tieredTx[K, V]) Delete(key K) {
+	t.Tx.Delete(key)
+	t.written[key] = struct{}{}
+}
+
+func (t *tieredTx[K, V]) ExpireKey(key K, ttl time.Duration) {
+	t.Get(key)
+	t.Tx.ExpireKey(key, ttl)
+}
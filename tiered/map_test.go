@@ -0,0 +1,128 @@
+package tiered
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trinhdaiphuc/go-memcache/gomap"
+)
+
+type User struct {
+	ID       int64
+	Username string
+	Email    string
+}
+
+func TestTieredMap(t *testing.T) {
+	userMap, err := NewMap[int, User](t.TempDir(), 2)
+	assert.NoError(t, err)
+	defer userMap.Close()
+
+	for i := 1; i <= 5; i++ {
+		userMap.SetWithTags(i, User{ID: int64(i), Username: fmt.Sprintf("user%d", i)}, "users")
+	}
+	assert.Equalf(t, userMap.Len(), 5, "userMap.Len() = %d; want 5", userMap.Len())
+	assert.Equalf(t, userMap.Stats().Keys, 2, "userMap.Stats().Keys = %d; want 2", userMap.Stats().Keys)
+
+	user, ok := userMap.Get(1)
+	assert.Truef(t, ok, "userMap.Get(1) = %v; want true", ok)
+	assert.Equalf(t, user.Username, "user1", "user.Username = %s; want user1", user.Username)
+	assert.Equalf(t, userMap.Len(), 5, "userMap.Len() = %d; want 5", userMap.Len())
+
+	userMap.ExpireKey(2, time.Minute)
+	ttl := userMap.TTLKey(2)
+	assert.Truef(t, ttl > 0 && ttl <= time.Minute, "userMap.TTLKey(2) = %s; want (0, 1m]", ttl)
+
+	userMap.Delete(3)
+	_, ok = userMap.Get(3)
+	assert.Falsef(t, ok, "userMap.Get(3) = %v; want false", ok)
+
+	count := userMap.InvalidateTag("users")
+	assert.Equalf(t, count, 4, "userMap.InvalidateTag(users) = %d; want 4", count)
+	assert.Zerof(t, userMap.Len(), "userMap.Len() = %d; want 0", userMap.Len())
+}
+
+func TestTieredMapTrySet(t *testing.T) {
+	userMap, err := NewMap[int, User](t.TempDir(), 1, WithMapOptions(gomap.WithCommandQueueSize(16)))
+	assert.NoError(t, err)
+	defer userMap.Close()
+
+	userMap.SetWithTags(1, User{ID: 1}, "users")
+	userMap.Set(2, User{ID: 2})
+
+	// 1 is spilled to disk: TrySet promotes it with its tags.
+	err = userMap.TrySet(1, User{ID: 1, Username: "user1"})
+	assert.NoError(t, err)
+	user, ok := userMap.Get(1)
+	assert.Truef(t, ok && user.Username == "user1", "userMap.Get(1) = %v, %v; want user1, true", user, ok)
+	assert.Equalf(t, userMap.Len(), 2, "userMap.Len() = %d; want 2", userMap.Len())
+
+	count := userMap.InvalidateTag("users")
+	assert.Equalf(t, count, 1, "userMap.InvalidateTag(users) = %d; want 1", count)
+	_, ok = userMap.Get(1)
+	assert.Falsef(t, ok, "userMap.Get(1) = %v after InvalidateTag; want false", ok)
+}
+
+func TestTieredMapKeysWhileSpilling(t *testing.T) {
+	userMap, err := NewMap[int, User](t.TempDir(), 4)
+	assert.NoError(t, err)
+	defer userMap.Close()
+
+	for i := 0; i < 8; i++ {
+		userMap.Set(i, User{ID: int64(i)})
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				userMap.Get((g + i) % 8)
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		keys := userMap.Keys()
+		assert.ElementsMatchf(t, keys, []int{0, 1, 2, 3, 4, 5, 6, 7}, "userMap.Keys() = %v; want [0 1 2 3 4 5 6 7]", keys)
+		assert.Lenf(t, userMap.Values(), 8, "len(userMap.Values()) = %d; want 8", len(userMap.Values()))
+	}
+}
+
+func TestTieredMapCompaction(t *testing.T) {
+	userMap, err := NewMap[int, User](t.TempDir(), 1, WithSegmentSize(256))
+	assert.NoError(t, err)
+	defer userMap.Close()
+
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 20; i++ {
+			userMap.Set(i, User{ID: int64(i), Username: fmt.Sprintf("user%d-%d", i, round)})
+		}
+	}
+
+	disk := userMap.(*tieredMap[int, User]).disk
+	before := len(disk.segments)
+	err = disk.compact()
+	assert.NoError(t, err)
+	assert.Lessf(t, len(disk.segments), before, "len(disk.segments) = %d; want < %d", len(disk.segments), before)
+
+	for i := 0; i < 20; i++ {
+		user, ok := userMap.Get(i)
+		assert.Truef(t, ok, "userMap.Get(%d) = %v; want true", i, ok)
+		assert.Equalf(t, user.Username, fmt.Sprintf("user%d-4", i), "user.Username = %s; want user%d-4", user.Username, i)
+	}
+}
@@ -0,0 +1,49 @@
+package tiered
+
+import (
+	"time"
+
+	"github.com/trinhdaiphuc/go-memcache/gomap"
+)
+
+type Option func(*options)
+
+type options struct {
+	segmentSize        int64
+	compactionInterval time.Duration
+	mapOptions         []gomap.Option
+}
+
+// WithSegmentSize sets the size in bytes after which a new segment file is
+// started.
+func WithSegmentSize(size int64) Option {
+	return func(o *options) {
+		o.segmentSize = size
+	}
+}
+
+// WithCompactionInterval sets how often segments holding mostly overwritten or
+// removed records are compacted.
+func WithCompactionInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.compactionInterval = interval
+	}
+}
+
+// WithMapOptions passes opts to the in-memory gomap.Map.
+func WithMapOptions(opts ...gomap.Option) Option {
+	return func(o *options) {
+		o.mapOptions = append(o.mapOptions, opts...)
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		segmentSize:        64 << 20,
+		compactionInterval: time.Minute,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,401 @@
+package tiered
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// record is the unit appended to a segment file. Expiry and tags are kept in
+// the in-memory index only, so changing them never rewrites the file.
+type record[K, V comparable] struct {
+	Key   K
+	Value V
+}
+
+type location struct {
+	segment   int
+	offset    int64
+	size      int64
+	expiresAt time.Time
+	tags      []string
+}
+
+func (l *location) isExpired() bool {
+	return !l.expiresAt.IsZero() && time.Now().After(l.expiresAt)
+}
+
+func (l *location) ttl() time.Duration {
+	if l.expiresAt.IsZero() {
+		return 0
+	}
+	return max(time.Until(l.expiresAt), 1)
+}
+
+type segment struct {
+	id   int
+	file *os.File
+	size int64
+	live int64
+}
+
+// diskStore is an append-only, segmented file store with an in-memory index.
+// Overwritten and removed records stay in their segment as garbage until the
+// segment is compacted.
+type diskStore[K, V comparable] struct {
+	mu          sync.Mutex
+	dir         string
+	segmentSize int64
+	segments    map[int]*segment
+	active      *segment
+	nextID      int
+	index       map[K]*location
+	expiresAt   time.Time
+}
+
+func newDiskStore[K, V comparable](dir string, segmentSize int64) (*diskStore[K, V], error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &diskStore[K, V]{
+		dir:         dir,
+		segmentSize: segmentSize,
+		segments:    make(map[int]*segment),
+		index:       make(map[K]*location),
+	}
+
+	err = s.rotate()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *diskStore[K, V]) segmentPath(id int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%06d.seg", id))
+}
+
+func (s *diskStore[K, V]) rotate() error {
+	file, err := os.OpenFile(s.segmentPath(s.nextID), os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	s.active = &segment{id: s.nextID, file: file}
+	s.segments[s.nextID] = s.active
+	s.nextID++
+	return nil
+}
+
+func (s *diskStore[K, V]) put(key K, value V, ttl time.Duration, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(record[K, V]{Key: key, Value: value})
+	if err != nil {
+		return err
+	}
+
+	loc, err := s.append(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if ttl > 0 {
+		loc.expiresAt = time.Now().Add(ttl)
+	}
+	loc.tags = tags
+	s.unlink(key)
+	s.index[key] = loc
+	return nil
+}
+
+func (s *diskStore[K, V]) append(data []byte) (*location, error) {
+	if s.active.size >= s.segmentSize {
+		err := s.rotate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	frame := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[4:], data)
+
+	_, err := s.active.file.WriteAt(frame, s.active.size)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := &location{segment: s.active.id, offset: s.active.size, size: int64(len(frame))}
+	s.active.size += loc.size
+	s.active.live += loc.size
+	return loc, nil
+}
+
+func (s *diskStore[K, V]) read(loc *location) (rec record[K, V], err error) {
+	frame := make([]byte, loc.size)
+	_, err = s.segments[loc.segment].file.ReadAt(frame, loc.offset)
+	if err != nil && err != io.EOF {
+		return rec, err
+	}
+
+	err = gob.NewDecoder(bytes.NewReader(frame[4:])).Decode(&rec)
+	return rec, err
+}
+
+// unlink drops key from the index and accounts its record as garbage.
+func (s *diskStore[K, V]) unlink(key K) {
+	loc, ok := s.index[key]
+	if !ok {
+		return
+	}
+
+	s.segments[loc.segment].live -= loc.size
+	delete(s.index, key)
+}
+
+// lookup returns the live location of key, dropping it if it has expired.
+func (s *diskStore[K, V]) lookup(key K) (*location, bool) {
+	if !s.expiresAt.IsZero() && time.Now().After(s.expiresAt) {
+		s.expiresAt = time.Time{}
+		for k := range s.index {
+			s.unlink(k)
+		}
+	}
+
+	loc, ok := s.index[key]
+	if !ok {
+		return nil, false
+	}
+	if loc.isExpired() {
+		s.unlink(key)
+		return nil, false
+	}
+	return loc, true
+}
+
+func (s *diskStore[K, V]) get(key K) (value V, ok bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loc, ok := s.lookup(key)
+	if !ok {
+		return value, false, nil
+	}
+
+	rec, err := s.read(loc)
+	if err != nil {
+		return value, false, err
+	}
+	return rec.Value, true, nil
+}
+
+// peek returns key as it was stored.
+func (s *diskStore[K, V]) peek(key K) (value V, ttl time.Duration, tags []string, ok bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.peekLocked(key)
+}
+
+func (s *diskStore[K, V]) peekLocked(key K) (value V, ttl time.Duration, tags []string, ok bool, err error) {
+	loc, ok := s.lookup(key)
+	if !ok {
+		return value, 0, nil, false, nil
+	}
+
+	rec, err := s.read(loc)
+	if err != nil {
+		return value, 0, nil, false, err
+	}
+	return rec.Value, loc.ttl(), loc.tags, true, nil
+}
+
+// take removes key from the store and returns it as it was stored.
+func (s *diskStore[K, V]) take(key K) (value V, ttl time.Duration, tags []string, ok bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ttl, tags, ok, err = s.peekLocked(key)
+	if ok {
+		s.unlink(key)
+	}
+	return value, ttl, tags, ok, err
+}
+
+func (s *diskStore[K, V]) remove(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unlink(key)
+}
+
+func (s *diskStore[K, V]) contains(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.lookup(key)
+	return ok
+}
+
+func (s *diskStore[K, V]) ttl(key K) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loc, ok := s.lookup(key)
+	if !ok {
+		return 0
+	}
+	return loc.ttl()
+}
+
+func (s *diskStore[K, V]) invalidateTag(tag string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for key, loc := range s.index {
+		if slices.Contains(loc.tags, tag) {
+			s.unlink(key)
+			count++
+		}
+	}
+	return count
+}
+
+// keys returns the live keys of the store that are not in skip.
+func (s *diskStore[K, V]) keys(skip map[K]struct{}) []K {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]K, 0, len(s.index))
+	for key := range s.index {
+		if _, skipped := skip[key]; skipped {
+			continue
+		}
+		if _, ok := s.lookup(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// values returns the live values of the keys that are not in skip. Records
+// that cannot be read are left out and the first error is returned.
+func (s *diskStore[K, V]) values(skip map[K]struct{}) ([]V, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	values := make([]V, 0, len(s.index))
+	for key := range s.index {
+		if _, skipped := skip[key]; skipped {
+			continue
+		}
+		loc, ok := s.lookup(key)
+		if !ok {
+			continue
+		}
+
+		rec, err := s.read(loc)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		values = append(values, rec.Value)
+	}
+	return values, firstErr
+}
+
+// expireAll drops every key of the store once ttl has elapsed.
+func (s *diskStore[K, V]) expireAll(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expiresAt = time.Time{}
+	if ttl > 0 {
+		s.expiresAt = time.Now().Add(ttl)
+	}
+}
+
+// compact rewrites the live records of every inactive segment whose live data
+// has dropped below half of its size, then deletes that segment.
+func (s *diskStore[K, V]) compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, seg := range s.segments {
+		if seg == s.active || seg.live*2 >= seg.size {
+			continue
+		}
+
+		for key, loc := range s.index {
+			if loc.segment != id {
+				continue
+			}
+			if loc.isExpired() {
+				s.unlink(key)
+				continue
+			}
+
+			frame := make([]byte, loc.size)
+			_, err := seg.file.ReadAt(frame, loc.offset)
+			if err != nil && err != io.EOF {
+				return err
+			}
+
+			newLoc, err := s.append(frame[4:])
+			if err != nil {
+				return err
+			}
+			newLoc.expiresAt = loc.expiresAt
+			newLoc.tags = loc.tags
+			s.index[key] = newLoc
+		}
+
+		delete(s.segments, id)
+		err := seg.file.Close()
+		if err != nil {
+			return err
+		}
+		err = os.Remove(seg.file.Name())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close closes and deletes every segment file: the spill tier only lives as
+// long as the cache in front of it.
+func (s *diskStore[K, V]) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	for id, seg := range s.segments {
+		err := seg.file.Close()
+		if err == nil {
+			err = os.Remove(seg.file.Name())
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(s.segments, id)
+	}
+	s.index = make(map[K]*location)
+	return firstErr
+}